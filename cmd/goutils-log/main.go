@@ -0,0 +1,432 @@
+// Command goutils-log pretty-prints JSON-lines logs written by logger.JSONEncoder.
+//
+// It reads a file, or stdin when no file or "-" is given, keeps the entries that
+// match the filters and renders them in the colored text format of logger.TextEncoder.
+// Lines that are not JSON objects are passed through unless a filter is set.
+//
+// Usage:
+//
+//	goutils-log [-level warn] [-since 1h] [-until 2024-05-01T12:00:00Z] [-field key=value]... [-f] [-no-color] [file]
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/isaacwallace123/GoUtils/color"
+	"github.com/isaacwallace123/GoUtils/logger"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// pollInterval is how often follow mode checks the file for new lines.
+var pollInterval = 250 * time.Millisecond
+
+func main() {
+	os.Exit(run(context.Background(), os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// fieldFilters collects repeated -field key=value flags.
+type fieldFilters map[string][]string
+
+// String implements flag.Value.
+func (f fieldFilters) String() string {
+	var parts []string
+	for k, values := range f {
+		for _, v := range values {
+			parts = append(parts, k+"="+v)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// Set implements flag.Value.
+func (f fieldFilters) Set(s string) error {
+	key, value, ok := strings.Cut(s, "=")
+	if !ok || key == "" {
+		return fmt.Errorf("want key=value, got %q", s)
+	}
+	f[key] = append(f[key], value)
+	return nil
+}
+
+// options holds the parsed command line.
+type options struct {
+	path    string
+	level   logger.Level
+	leveled bool
+	since   time.Time
+	until   time.Time
+	fields  fieldFilters
+	follow  bool
+	noColor bool
+}
+
+// filtered reports whether any filter is set.
+func (o *options) filtered() bool {
+	return o.leveled || !o.since.IsZero() || !o.until.IsZero() || len(o.fields) > 0
+}
+
+// parseFlags parses args into options. now anchors relative -since and -until values.
+func parseFlags(args []string, stderr io.Writer, now time.Time) (*options, error) {
+	o := &options{fields: fieldFilters{}}
+	fs := flag.NewFlagSet("goutils-log", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	level := fs.String("level", "", "minimum level to show, e.g. info or warn")
+	since := fs.String("since", "", "show entries at or after this time (RFC 3339 or a duration ago, e.g. 15m)")
+	until := fs.String("until", "", "show entries before this time (RFC 3339 or a duration ago)")
+	fs.Var(o.fields, "field", "show entries whose field key has value (repeatable; values for one key are alternatives)")
+	fs.BoolVar(&o.follow, "f", false, "keep reading as the file grows")
+	fs.BoolVar(&o.noColor, "no-color", false, "disable colors")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: goutils-log [flags] [file]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 1 {
+		return nil, errors.New("at most one file may be given")
+	}
+	o.path = fs.Arg(0)
+
+	var err error
+	if *level != "" {
+		if o.level, err = logger.ParseLevel(*level); err != nil {
+			return nil, err
+		}
+		o.leveled = true
+	}
+	if o.since, err = parseTime(*since, now); err != nil {
+		return nil, fmt.Errorf("-since: %w", err)
+	}
+	if o.until, err = parseTime(*until, now); err != nil {
+		return nil, fmt.Errorf("-until: %w", err)
+	}
+	return o, nil
+}
+
+// parseTime parses an RFC 3339 time or a duration before now. An empty string is the zero time.
+func parseTime(s string, now time.Time) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	return time.Parse(time.RFC3339Nano, s)
+}
+
+// run executes the command and returns its exit code.
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	o, err := parseFlags(args, stderr, time.Now())
+	if err == flag.ErrHelp {
+		return 0
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, "goutils-log:", err)
+		return 2
+	}
+
+	in := stdin
+	var file *os.File
+	if o.path != "" && o.path != "-" {
+		if file, err = os.Open(o.path); err != nil {
+			fmt.Fprintln(stderr, "goutils-log:", err)
+			return 1
+		}
+		defer file.Close()
+		in = file
+	}
+	follow := o.follow && file != nil
+	if follow {
+		// Stop following on Ctrl-C so buffered output is flushed before exiting.
+		var stop context.CancelFunc
+		ctx, stop = signal.NotifyContext(ctx, os.Interrupt)
+		defer stop()
+	}
+
+	enc := logger.TextEncoder{NoColor: o.noColor || color.ProfileFor(stdout) == color.ProfileNone}
+	out := bufio.NewWriter(stdout)
+	defer out.Flush()
+	var buf bytes.Buffer
+	err = readLines(ctx, in, file, follow, out.Flush, func(line []byte) error {
+		e, ok := parseEntry(line)
+		if !ok {
+			if o.filtered() {
+				return nil
+			}
+			_, err := out.Write(append(line, '\n'))
+			return err
+		}
+		if !o.match(e) {
+			return nil
+		}
+		buf.Reset()
+		enc.Encode(&buf, e)
+		_, err := out.Write(buf.Bytes())
+		return err
+	})
+	if err != nil && !errors.Is(err, context.Canceled) {
+		fmt.Fprintln(stderr, "goutils-log:", err)
+		return 1
+	}
+	return 0
+}
+
+// readLines calls fn for every line of r without its line ending. In follow mode it keeps
+// polling file for new data until ctx is done, starting over if the file is truncated and
+// switching to the new file at the same path once the old one has been rotated away.
+// It calls idle whenever it runs out of input.
+func readLines(ctx context.Context, r io.Reader, file *os.File, follow bool, idle func() error, fn func([]byte) error) error {
+	br := bufio.NewReader(r)
+	var partial []byte
+	var offset int64
+	rotated := false
+	var reopened *os.File
+	defer func() {
+		if reopened != nil {
+			reopened.Close()
+		}
+	}()
+	for {
+		line, err := br.ReadBytes('\n')
+		offset += int64(len(line))
+		partial = append(partial, line...)
+		if err == nil {
+			if err := fn(bytes.TrimRight(partial, "\r\n")); err != nil {
+				return err
+			}
+			partial = partial[:0]
+			continue
+		}
+		if err != io.EOF {
+			return err
+		}
+		if !follow || rotated {
+			if len(partial) > 0 {
+				if err := fn(bytes.TrimRight(partial, "\r\n")); err != nil {
+					return err
+				}
+			}
+			if !follow {
+				return nil
+			}
+			// The old file is drained; carry on with the one now at its path.
+			next, err := os.Open(file.Name())
+			if err != nil {
+				return err
+			}
+			if reopened != nil {
+				reopened.Close()
+			}
+			reopened, file = next, next
+			br.Reset(file)
+			partial, offset, rotated = partial[:0], 0, false
+			continue
+		}
+		if err := idle(); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(pollInterval):
+		}
+		info, err := file.Stat()
+		if err != nil {
+			continue
+		}
+		if current, err := os.Stat(file.Name()); err == nil && !os.SameFile(info, current) {
+			// Read what was appended before the rename, then reopen.
+			rotated = true
+			continue
+		}
+		if info.Size() < offset {
+			if _, err := file.Seek(0, io.SeekStart); err != nil {
+				return err
+			}
+			br.Reset(file)
+			partial, offset = partial[:0], 0
+		}
+	}
+}
+
+// parseEntry converts a JSON log line into an entry, keeping the field order of the line.
+func parseEntry(line []byte) (*logger.Entry, bool) {
+	dec := json.NewDecoder(bytes.NewReader(line))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, false
+	}
+	e := &logger.Entry{Level: logger.LevelInfo, Tag: logger.LevelTag(logger.LevelInfo)}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, false
+		}
+		key, _ := tok.(string)
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, false
+		}
+		value := rawValue(raw)
+		s, isString := value.(string)
+		switch {
+		case key == "time" && parseEntryTime(raw, &e.Time):
+		case key == "level" && isString:
+			e.Level, e.Tag = parseLevel(s)
+		case key == "caller" && isString:
+			fn := e.Caller.Function
+			e.Caller = parseCaller(s)
+			e.Caller.Function = fn
+		case key == "func" && isString:
+			e.Caller.Function = s
+		case key == "logger" && isString:
+			e.Logger = s
+		case key == "msg" && isString:
+			e.Message = s
+		case key == "stack" && isString:
+			e.Stack = s
+		default:
+			e.Fields = append(e.Fields, logger.F(key, value))
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, false
+	}
+	return e, true
+}
+
+// rawJSON is an object or array field value kept as compact JSON text.
+type rawJSON string
+
+// String implements fmt.Stringer.
+func (r rawJSON) String() string {
+	return string(r)
+}
+
+// rawValue decodes strings and booleans, keeps numbers and null as written and
+// objects and arrays as compact JSON text.
+func rawValue(raw json.RawMessage) interface{} {
+	switch raw[0] {
+	case '"':
+		var s string
+		if json.Unmarshal(raw, &s) == nil {
+			return s
+		}
+	case 't', 'f':
+		return raw[0] == 't'
+	case '{', '[':
+		var compact bytes.Buffer
+		if json.Compact(&compact, raw) == nil {
+			return rawJSON(compact.String())
+		}
+	}
+	return json.Number(raw)
+}
+
+// entryTimeLayouts are the string layouts tried for the time key: RFC 3339, which
+// JSONEncoder writes by default, and the zone-less TimeFormat layouts, read as local time.
+var entryTimeLayouts = []string{
+	time.RFC3339Nano,
+	string(logger.TimeFormatMillis),
+	string(logger.TimeFormatDateTime),
+}
+
+// parseEntryTime parses a timestamp in one of entryTimeLayouts or a Unix epoch in seconds,
+// milliseconds or nanoseconds into t. It reports false, leaving t unchanged, for other values.
+func parseEntryTime(raw json.RawMessage, t *time.Time) bool {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		for _, layout := range entryTimeLayouts {
+			if parsed, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+				*t = parsed
+				return true
+			}
+		}
+		return false
+	}
+	n, err := strconv.ParseInt(string(raw), 10, 64)
+	if err != nil {
+		return false
+	}
+	switch {
+	case n < 1e11:
+		*t = time.Unix(n, 0)
+	case n < 1e14:
+		*t = time.UnixMilli(n)
+	default:
+		*t = time.Unix(0, n)
+	}
+	return true
+}
+
+// parseLevel resolves a level name. Names the logger does not know, such as custom
+// levels registered by another program, keep their name and sort with INFO.
+func parseLevel(name string) (logger.Level, logger.LogTag) {
+	if level, err := logger.ParseLevel(name); err == nil {
+		return level, logger.LevelTag(level)
+	}
+	return logger.LevelInfo, logger.LogTag{Name: strings.ToUpper(name), Color: color.White}
+}
+
+// parseCaller splits "file.go:42" into a Caller.
+func parseCaller(s string) logger.Caller {
+	i := strings.LastIndexByte(s, ':')
+	if i < 0 {
+		return logger.Caller{File: s}
+	}
+	n, err := strconv.Atoi(s[i+1:])
+	if err != nil {
+		return logger.Caller{File: s}
+	}
+	return logger.Caller{File: s[:i], Line: n}
+}
+
+// match reports whether e passes the level, time and field filters.
+func (o *options) match(e *logger.Entry) bool {
+	if o.leveled && e.Level < o.level {
+		return false
+	}
+	if !o.since.IsZero() && e.Time.Before(o.since) {
+		return false
+	}
+	if !o.until.IsZero() && !e.Time.Before(o.until) {
+		return false
+	}
+	for key, want := range o.fields {
+		if !fieldMatches(e, key, want) {
+			return false
+		}
+	}
+	return true
+}
+
+// fieldMatches reports whether the field key of e, or its logger name for "logger", equals one of want.
+func fieldMatches(e *logger.Entry, key string, want []string) bool {
+	var got []string
+	if key == "logger" {
+		got = append(got, e.Logger)
+	}
+	for _, f := range e.Fields {
+		if f.Key == key {
+			got = append(got, fmt.Sprint(f.Any()))
+		}
+	}
+	for _, g := range got {
+		for _, w := range want {
+			if g == w {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"github.com/isaacwallace123/GoUtils/logger"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// sample holds JSON lines as written by logger.JSONEncoder, plus one plain line.
+const sample = `{"time":"2024-05-01T12:00:00Z","level":"info","caller":"main.go:42","func":"main.main","msg":"server started","port":8080}
+{"time":"2024-05-01T12:05:00Z","level":"warn","caller":"db.go:7","logger":"db","msg":"slow query","table":"users","took":"1.2s"}
+not json
+{"time":"2024-05-01T12:10:00Z","level":"error","msg":"request failed","status":500,"req":{"id":"abc","tags":["a","b"]}}
+`
+
+// runCLI runs the command on stdin and returns its exit code, stdout and stderr.
+func runCLI(t *testing.T, stdin string, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), args, strings.NewReader(stdin), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+// TestRender checks entries are re-rendered in the text format and plain lines pass through.
+func TestRender(t *testing.T) {
+	code, out, errOut := runCLI(t, sample, "-no-color")
+	if code != 0 {
+		t.Fatalf("exit %d: %s", code, errOut)
+	}
+	want := "[2024-05-01 12:00:00] [INFO] [main.go:42 main.main] server started port=8080\n" +
+		"[2024-05-01 12:05:00] [WARN] [db.go:7] [db] slow query table=users took=1.2s\n" +
+		"not json\n" +
+		`[2024-05-01 12:10:00] [ERROR] request failed status=500 req="{\"id\":\"abc\",\"tags\":[\"a\",\"b\"]}"` + "\n"
+	if out != want {
+		t.Errorf("got\n%s\nwant\n%s", out, want)
+	}
+}
+
+// TestColor checks level tags are colored unless -no-color is set.
+func TestColor(t *testing.T) {
+	t.Setenv("FORCE_COLOR", "1")
+	_, out, _ := runCLI(t, sample)
+	if !strings.Contains(out, logger.WarnTag.Color+"[WARN]") {
+		t.Errorf("expected colored WARN tag: %q", out)
+	}
+}
+
+// TestFilters checks the level, time range and field filters, which also drop plain lines.
+func TestFilters(t *testing.T) {
+	cases := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"-level", "warn"}, []string{"slow query", "request failed"}},
+		{[]string{"-since", "2024-05-01T12:05:00Z", "-until", "2024-05-01T12:10:00Z"}, []string{"slow query"}},
+		{[]string{"-field", "table=users"}, []string{"slow query"}},
+		{[]string{"-field", "status=500", "-field", "status=8080"}, []string{"request failed"}},
+		{[]string{"-field", "logger=db"}, []string{"slow query"}},
+		{[]string{"-level", "error", "-field", "table=users"}, nil},
+	}
+	for _, c := range cases {
+		code, out, errOut := runCLI(t, sample, append(c.args, "-no-color")...)
+		if code != 0 {
+			t.Fatalf("%v: exit %d: %s", c.args, code, errOut)
+		}
+		lines := strings.Split(strings.TrimSpace(out), "\n")
+		if out == "" {
+			lines = nil
+		}
+		if len(lines) != len(c.want) {
+			t.Errorf("%v: got %q, want %q", c.args, lines, c.want)
+			continue
+		}
+		for i, w := range c.want {
+			if !strings.Contains(lines[i], w) {
+				t.Errorf("%v: line %d = %q, want %q", c.args, i, lines[i], w)
+			}
+		}
+	}
+}
+
+// TestBadFlags checks invalid flags exit with status 2.
+func TestBadFlags(t *testing.T) {
+	for _, args := range [][]string{{"-level", "loud"}, {"-since", "yesterday"}, {"-field", "novalue"}, {"a", "b"}} {
+		if code, _, _ := runCLI(t, "", args...); code != 2 {
+			t.Errorf("%v: exit %d, want 2", args, code)
+		}
+	}
+}
+
+// TestParseEntryTime checks RFC 3339, TimeFormat layouts and epoch timestamps.
+func TestParseEntryTime(t *testing.T) {
+	want := time.Date(2024, 5, 1, 12, 30, 45, 0, time.UTC)
+	local := time.Date(2024, 5, 1, 12, 30, 45, 0, time.Local)
+	cases := map[string]time.Time{
+		`"2024-05-01T12:30:45Z"`:    want,
+		`1714566645`:                want,
+		`1714566645000`:             want,
+		`1714566645000000000`:       want,
+		`"2024-05-01 12:30:45"`:     local,
+		`"2024-05-01 12:30:45.000"`: local,
+	}
+	for raw, want := range cases {
+		var got time.Time
+		if !parseEntryTime([]byte(raw), &got) || !got.Equal(want) {
+			t.Errorf("%s: got %v", raw, got)
+		}
+	}
+	var got time.Time
+	if parseEntryTime([]byte(`"yesterday"`), &got) || !got.IsZero() {
+		t.Errorf("unparseable time should be rejected, got %v", got)
+	}
+}
+
+// TestRenderWithoutTime checks entries without a usable time render no timestamp and keep an unknown one as a field.
+func TestRenderWithoutTime(t *testing.T) {
+	_, out, _ := runCLI(t, `{"level":"info","msg":"no time"}`+"\n"+`{"time":"soon","level":"info","msg":"odd time"}`+"\n", "-no-color")
+	want := "[INFO] no time\n[INFO] odd time time=soon\n"
+	if out != want {
+		t.Errorf("got %q, want %q", out, want)
+	}
+}
+
+// lockedBuffer is a bytes.Buffer safe for concurrent use.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// TestFollow checks -f prints lines appended after startup, including a line written in two parts.
+func TestFollow(t *testing.T) {
+	pollInterval = 5 * time.Millisecond
+	path := filepath.Join(t.TempDir(), "app.log")
+	if err := os.WriteFile(path, []byte(`{"level":"info","msg":"first"}`+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var stdout, stderr lockedBuffer
+	done := make(chan int)
+	go func() { done <- run(ctx, []string{"-f", "-no-color", path}, nil, &stdout, &stderr) }()
+
+	waitFor := func(substr string) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for !strings.Contains(stdout.String(), substr) {
+			if time.Now().After(deadline) {
+				t.Fatalf("%q not printed, got %q", substr, stdout.String())
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+	waitFor("first")
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"level":"warn",`)
+	time.Sleep(20 * time.Millisecond)
+	f.WriteString(`"msg":"second"}` + "\n")
+	f.Close()
+	waitFor("[WARN] second")
+
+	cancel()
+	if code := <-done; code != 0 {
+		t.Errorf("exit %d: %s", code, stderr.String())
+	}
+}
+
+// TestFollowRotation checks -f drains a file rotated by rename and continues with the new one.
+func TestFollowRotation(t *testing.T) {
+	pollInterval = 5 * time.Millisecond
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	if err := os.WriteFile(path, []byte(`{"level":"info","msg":"first"}`+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var stdout, stderr lockedBuffer
+	done := make(chan int)
+	go func() { done <- run(ctx, []string{"-f", "-no-color", path}, nil, &stdout, &stderr) }()
+
+	waitFor := func(substr string) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for !strings.Contains(stdout.String(), substr) {
+			if time.Now().After(deadline) {
+				t.Fatalf("%q not printed, got %q", substr, stdout.String())
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+	waitFor("first")
+
+	old, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(path, filepath.Join(dir, "app-1.log")); err != nil {
+		t.Fatal(err)
+	}
+	old.WriteString(`{"level":"info","msg":"late"}` + "\n")
+	old.Close()
+	if err := os.WriteFile(path, []byte(`{"level":"warn","msg":"rotated"}`+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	waitFor("[WARN] rotated")
+	if !strings.Contains(stdout.String(), "late") {
+		t.Errorf("line written before the switch was lost: %q", stdout.String())
+	}
+
+	cancel()
+	if code := <-done; code != 0 {
+		t.Errorf("exit %d: %s", code, stderr.String())
+	}
+}
@@ -56,6 +56,23 @@ var HTTPMethodToColor = map[string]string{
 	"OPTIONS": OptionsColor,
 }
 
+// StatusColor returns the semantic color for an HTTP status code class:
+// 2xx success, 3xx cyan, 4xx warning and 5xx error.
+func StatusColor(code int) string {
+	switch {
+	case code >= 500:
+		return ErrorColor
+	case code >= 400:
+		return WarnColor
+	case code >= 300:
+		return Cyan
+	case code >= 200:
+		return SuccessColor
+	default:
+		return White
+	}
+}
+
 // Extended named colors and ANSI helpers remain the same as before...
 var NameToCode = map[string]string{
 	"reset":          Reset,
@@ -90,7 +107,12 @@ var NameToCode = map[string]string{
 	"debug":   DebugColor,
 }
 
+// Paint wraps s in the given color code and a reset, or returns s unchanged
+// when colors are disabled (see CurrentProfile).
 func Paint(s, color string) string {
+	if !Enabled() {
+		return s
+	}
 	return color + s + Reset
 }
 
@@ -48,6 +48,16 @@ func TestHTTPMethodToColorMap(t *testing.T) {
 	}
 }
 
+// TestStatusColor checks that status codes map to the color of their class.
+func TestStatusColor(t *testing.T) {
+	cases := map[int]string{200: SuccessColor, 204: SuccessColor, 301: Cyan, 404: WarnColor, 503: ErrorColor, 101: White}
+	for code, want := range cases {
+		if got := StatusColor(code); got != want {
+			t.Errorf("StatusColor(%d) = %q, want %q", code, got, want)
+		}
+	}
+}
+
 // TestNameToCode ensures color names resolve to ANSI codes (including semantic names).
 func TestNameToCode(t *testing.T) {
 	// Basic color
@@ -65,6 +75,8 @@ func TestNameToCode(t *testing.T) {
 
 // TestPaint ensures Paint wraps the input string in the correct color code and reset.
 func TestPaint(t *testing.T) {
+	defer SetProfile(CurrentProfile())
+	SetProfile(ProfileBasic)
 	s := "Hello"
 	c := BrightBlue
 	expected := c + s + Reset
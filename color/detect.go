@@ -0,0 +1,97 @@
+package color
+
+import (
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Profile describes how many colors an output supports.
+type Profile int
+
+const (
+	ProfileNone      Profile = iota // no ANSI codes
+	ProfileBasic                    // 16 colors
+	Profile256                      // 256 colors
+	ProfileTrueColor                // 24-bit colors
+)
+
+var (
+	detectOnce sync.Once
+	profile    atomic.Int32
+)
+
+// IsTerminal reports whether f is connected to a terminal.
+func IsTerminal(f *os.File) bool {
+	if f == nil {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// ProfileFor detects the color profile of w from the environment:
+// NO_COLOR disables colors, FORCE_COLOR (1, 2 or 3) forces them even when w is not a terminal,
+// TERM=dumb disables them, and COLORTERM=truecolor or a 256color TERM raise the profile.
+// Writers other than terminal *os.File values get ProfileNone unless FORCE_COLOR is set.
+func ProfileFor(w io.Writer) Profile {
+	if os.Getenv("NO_COLOR") != "" {
+		return ProfileNone
+	}
+	if force, ok := os.LookupEnv("FORCE_COLOR"); ok {
+		switch strings.ToLower(force) {
+		case "0", "false", "no", "off":
+			return ProfileNone
+		case "2":
+			return Profile256
+		case "3":
+			return ProfileTrueColor
+		default:
+			return max(ProfileBasic, envProfile())
+		}
+	}
+	if os.Getenv("TERM") == "dumb" {
+		return ProfileNone
+	}
+	f, ok := w.(*os.File)
+	if !ok || !IsTerminal(f) {
+		return ProfileNone
+	}
+	return envProfile()
+}
+
+// envProfile infers the richest profile advertised by COLORTERM and TERM.
+func envProfile() Profile {
+	switch strings.ToLower(os.Getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return ProfileTrueColor
+	}
+	if strings.Contains(os.Getenv("TERM"), "256color") {
+		return Profile256
+	}
+	return ProfileBasic
+}
+
+// CurrentProfile returns the profile used by Paint, detecting it from stdout on first use.
+func CurrentProfile() Profile {
+	detectOnce.Do(func() {
+		profile.Store(int32(ProfileFor(os.Stdout)))
+	})
+	return Profile(profile.Load())
+}
+
+// SetProfile overrides the detected profile used by Paint.
+func SetProfile(p Profile) {
+	detectOnce.Do(func() {})
+	profile.Store(int32(p))
+}
+
+// Enabled reports whether Paint emits color codes.
+func Enabled() bool {
+	return CurrentProfile() != ProfileNone
+}
@@ -0,0 +1,56 @@
+package color
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+// TestProfileForEnv checks NO_COLOR, FORCE_COLOR, TERM and COLORTERM handling.
+func TestProfileForEnv(t *testing.T) {
+	var buf bytes.Buffer
+	cases := []struct {
+		env  map[string]string
+		want Profile
+	}{
+		{map[string]string{}, ProfileNone},
+		{map[string]string{"FORCE_COLOR": "1"}, ProfileBasic},
+		{map[string]string{"FORCE_COLOR": "1", "COLORTERM": "truecolor"}, ProfileTrueColor},
+		{map[string]string{"FORCE_COLOR": "1", "TERM": "xterm-256color"}, Profile256},
+		{map[string]string{"FORCE_COLOR": "3"}, ProfileTrueColor},
+		{map[string]string{"FORCE_COLOR": "0"}, ProfileNone},
+		{map[string]string{"FORCE_COLOR": "1", "NO_COLOR": "1"}, ProfileNone},
+	}
+	for _, tc := range cases {
+		for _, key := range []string{"NO_COLOR", "FORCE_COLOR", "TERM", "COLORTERM"} {
+			t.Setenv(key, tc.env[key])
+			if _, ok := tc.env[key]; !ok {
+				os.Unsetenv(key)
+			}
+		}
+		if got := ProfileFor(&buf); got != tc.want {
+			t.Errorf("ProfileFor with %v = %d, want %d", tc.env, got, tc.want)
+		}
+	}
+}
+
+// TestIsTerminal checks that regular files are not treated as terminals.
+func TestIsTerminal(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "out")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if IsTerminal(f) || IsTerminal(nil) {
+		t.Error("files and nil should not be terminals")
+	}
+}
+
+// TestPaintDisabled checks that Paint returns plain text when colors are off.
+func TestPaintDisabled(t *testing.T) {
+	defer SetProfile(CurrentProfile())
+	SetProfile(ProfileNone)
+	if got := Paint("plain", Red); got != "plain" {
+		t.Errorf("Paint with colors disabled: got %q", got)
+	}
+}
@@ -16,6 +16,15 @@ func ToString(v any) string {
 	return string(data)
 }
 
+// Encode encodes a value into a compact JSON string and reports any encoding error.
+func Encode(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
 // ToStringIndent encodes a value into a pretty JSON string.
 func ToStringIndent(v any) string {
 	data, err := json.MarshalIndent(v, "", "  ")
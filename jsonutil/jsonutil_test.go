@@ -20,6 +20,16 @@ func TestToStringAndFromString(t *testing.T) {
 	}
 }
 
+// TestEncode checks that Encode returns JSON for valid input and an error otherwise.
+func TestEncode(t *testing.T) {
+	if s, err := Encode(map[string]int{"a": 1}); err != nil || s != `{"a":1}` {
+		t.Errorf("Encode failed: got %q, %v", s, err)
+	}
+	if _, err := Encode(make(chan int)); err == nil {
+		t.Error("Encode should fail for unsupported types")
+	}
+}
+
 // TestCompactAndPretty checks JSON pretty printing and compaction.
 func TestCompactAndPretty(t *testing.T) {
 	jsonMin := `{"a":1,"b":2}`
@@ -0,0 +1,138 @@
+package logger
+
+import (
+	"fmt"
+	"github.com/isaacwallace123/GoUtils/color"
+	"github.com/isaacwallace123/GoUtils/timeutil"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RequestIDHeader is the header AccessLog reads request IDs from and echoes them in.
+const RequestIDHeader = "X-Request-ID"
+
+// statusRecorder captures the status code and body size written by a handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	if r.status == 0 {
+		r.status = code
+	}
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *statusRecorder) Write(p []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(p)
+	r.bytes += int64(n)
+	return n, err
+}
+
+// Flush lets streaming handlers flush through the recorder.
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// AccessLog returns middleware that logs one entry per request through l.
+// Each request gets a request ID (taken from X-Request-ID or freshly minted) in its
+// context and response headers. With a text encoder the entry is a compact
+// "GET /path 200 512B 1.2ms" line with the method and status colored; with other
+// encoders and sinks it is an "http request" entry with structured fields.
+// 5xx responses are logged at ERROR, 4xx at WARN and the rest at INFO.
+func AccessLog(l *Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			ctx := r.Context()
+			if id := r.Header.Get(RequestIDHeader); id != "" && RequestIDFromContext(ctx) == "" {
+				ctx = ContextWithRequestID(ctx, id)
+			}
+			ctx, id := EnsureRequestID(ctx)
+			w.Header().Set(RequestIDHeader, id)
+
+			rec := &statusRecorder{ResponseWriter: w}
+			next.ServeHTTP(rec, r.WithContext(ctx))
+			if rec.status == 0 {
+				rec.status = http.StatusOK
+			}
+
+			level := LevelInfo
+			switch {
+			case rec.status >= 500:
+				level = LevelError
+			case rec.status >= 400:
+				level = LevelWarn
+			}
+			if !l.Enabled(level) {
+				return
+			}
+			l.write(accessEntry(l, level, r, rec, id, time.Since(start)))
+		})
+	}
+}
+
+// accessEntry builds the access log entry for a finished request.
+func accessEntry(l *Logger, level Level, r *http.Request, rec *statusRecorder, id string, latency time.Duration) *Entry {
+	e := &Entry{
+		Time:  l.now(),
+		Level: level,
+		Tag:   l.tag(level),
+	}
+	text, colored := l.textOutput()
+	if text {
+		method, status := r.Method, strconv.Itoa(rec.status)
+		if colored {
+			method = colorFor(method) + method + reset
+			status = color.StatusColor(rec.status) + status + reset
+		}
+		e.Message = fmt.Sprintf("%s %s %s %dB %s", method, r.URL.Path, status, rec.bytes, timeutil.FormatLatency(latency))
+		e.Fields = []Field{String(RequestIDKey, id)}
+		return e
+	}
+	e.Message = "http request"
+	e.Fields = []Field{
+		String("method", r.Method),
+		String("path", r.URL.Path),
+		Int("status", rec.status),
+		Int64("bytes", rec.bytes),
+		String("latency", timeutil.FormatLatency(latency)),
+		Float64("latency_ms", float64(latency)/float64(time.Millisecond)),
+		String(RequestIDKey, id),
+	}
+	return e
+}
+
+// colorFor returns the color of an HTTP method, or white for unknown methods.
+func colorFor(method string) string {
+	if c, ok := color.HTTPMethodToColor[method]; ok {
+		return c
+	}
+	return color.White
+}
+
+// textOutput reports whether the logger writes with a TextEncoder and whether that encoder uses colors.
+func (l *Logger) textOutput() (text, colored bool) {
+	ws, ok := l.sink.(*writerSink)
+	if !ok {
+		return false, false
+	}
+	enc, ok := ws.enc.(TextEncoder)
+	if !ok {
+		return false, false
+	}
+	return true, enc.colored()
+}
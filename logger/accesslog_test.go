@@ -0,0 +1,68 @@
+package logger
+
+import (
+	"bytes"
+	"github.com/isaacwallace123/GoUtils/color"
+	"github.com/isaacwallace123/GoUtils/jsonutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// teapot is a handler that writes a fixed body and status.
+func teapot(status int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if RequestIDFromContext(r.Context()) == "" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(status)
+		w.Write([]byte("hello"))
+	})
+}
+
+// TestAccessLogText checks the colored one-line format and request ID propagation.
+func TestAccessLogText(t *testing.T) {
+	var out bytes.Buffer
+	l := New(WithOutput(&out), WithEncoder(TextEncoder{Color: ColorAlways}))
+	h := AccessLog(l)(teapot(http.StatusNotFound))
+
+	req := httptest.NewRequest(http.MethodDelete, "/items/7", nil)
+	req.Header.Set(RequestIDHeader, "req-42")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Header().Get(RequestIDHeader) != "req-42" {
+		t.Errorf("request ID not echoed: %q", rec.Header().Get(RequestIDHeader))
+	}
+	line := out.String()
+	want := color.DeleteColor + "DELETE" + color.Reset + " /items/7 " + color.WarnColor + "404" + color.Reset + " 5B "
+	if !strings.Contains(line, "[WARN]") || !strings.Contains(line, want) || !strings.Contains(line, "request_id=req-42") {
+		t.Errorf("unexpected access line: %q", line)
+	}
+}
+
+// TestAccessLogJSON checks the structured fields in JSON mode.
+func TestAccessLogJSON(t *testing.T) {
+	var out bytes.Buffer
+	l := New(WithOutput(&out), WithEncoder(JSONEncoder{}))
+	h := AccessLog(l)(teapot(http.StatusCreated))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/items", nil))
+
+	obj := jsonutil.ToObject(out.String())
+	if obj == nil {
+		t.Fatalf("invalid JSON: %q", out.String())
+	}
+	if obj["msg"] != "http request" || obj["method"] != "POST" || obj["path"] != "/items" ||
+		obj["status"] != float64(201) || obj["bytes"] != float64(5) || obj["level"] != "info" {
+		t.Errorf("unexpected fields: %v", obj)
+	}
+	if obj["request_id"] != rec.Header().Get(RequestIDHeader) || obj["request_id"] == "" {
+		t.Errorf("request ID mismatch: %v vs %q", obj["request_id"], rec.Header().Get(RequestIDHeader))
+	}
+	if _, ok := obj["latency"].(string); !ok {
+		t.Errorf("latency should be a formatted string: %v", obj["latency"])
+	}
+}
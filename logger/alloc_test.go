@@ -0,0 +1,30 @@
+//go:build !race
+
+package logger
+
+import (
+	"io"
+	"testing"
+	"time"
+)
+
+// TestZeroAllocs checks that disabled calls and enabled calls with typed fields do not allocate.
+// The race detector makes sync.Pool drop items at random, so it is excluded.
+func TestZeroAllocs(t *testing.T) {
+	for _, enc := range []Encoder{TextEncoder{}, JSONEncoder{}, LogfmtEncoder{}} {
+		l := New(WithOutput(io.Discard), WithEncoder(enc), WithCallerFunction(true)).With("service", "api")
+		cases := map[string]func(){
+			"disabled": func() { l.Debug("tick %d", 42) },
+			"message":  func() { l.Info("request handled") },
+			"fields": func() {
+				l.LogFields(LevelInfo, "request handled",
+					String("path", "/users"), Int("status", 200), Duration("latency", 3*time.Millisecond), Bool("cached", true))
+			},
+		}
+		for name, fn := range cases {
+			if n := testing.AllocsPerRun(100, fn); n != 0 {
+				t.Errorf("%T %s: %v allocations per call, want 0", enc, name, n)
+			}
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package logger
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrSinkClosed is returned when writing to a sink that has been closed.
+var ErrSinkClosed = errors.New("logger: sink closed")
+
+// Flusher is implemented by sinks that buffer entries before writing them.
+type Flusher interface {
+	Flush() error
+}
+
+// OverflowPolicy decides what an AsyncSink does when its queue is full.
+type OverflowPolicy int
+
+const (
+	// Block waits for room in the queue.
+	Block OverflowPolicy = iota
+	// DropNewest discards the entry being written.
+	DropNewest
+	// DropOldest discards the oldest queued entry to make room.
+	DropOldest
+)
+
+// AsyncOptions configures an AsyncSink.
+type AsyncOptions struct {
+	// Size is the queue capacity. Defaults to 1024.
+	Size int
+	// Overflow is the policy applied when the queue is full. Defaults to Block.
+	Overflow OverflowPolicy
+	// OnError, if set, receives errors returned by the wrapped sink.
+	OnError func(error)
+}
+
+// AsyncSink queues entries in a bounded ring buffer and writes them to another sink
+// from a background goroutine, so logging calls do not wait on I/O.
+type AsyncSink struct {
+	inner   Sink
+	policy  OverflowPolicy
+	onError func(error)
+
+	mu       sync.Mutex
+	notEmpty *sync.Cond
+	notFull  *sync.Cond
+	idle     *sync.Cond
+	queue    []*Entry
+	head     int
+	count    int
+	writing  bool
+	closed   bool
+	done     chan struct{}
+	dropped  atomic.Uint64
+}
+
+// NewAsyncSink starts a background writer that forwards queued entries to inner.
+// Call Close on shutdown to drain the queue.
+func NewAsyncSink(inner Sink, opts AsyncOptions) *AsyncSink {
+	if opts.Size <= 0 {
+		opts.Size = 1024
+	}
+	s := &AsyncSink{
+		inner:   inner,
+		policy:  opts.Overflow,
+		onError: opts.OnError,
+		queue:   make([]*Entry, opts.Size),
+		done:    make(chan struct{}),
+	}
+	s.notEmpty = sync.NewCond(&s.mu)
+	s.notFull = sync.NewCond(&s.mu)
+	s.idle = sync.NewCond(&s.mu)
+	go s.run()
+	return s
+}
+
+// Write implements Sink by queueing a copy of e.
+func (s *AsyncSink) Write(e *Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for !s.closed && s.count == len(s.queue) {
+		switch s.policy {
+		case DropNewest:
+			s.dropped.Add(1)
+			return nil
+		case DropOldest:
+			s.queue[s.head] = nil
+			s.head = (s.head + 1) % len(s.queue)
+			s.count--
+			s.dropped.Add(1)
+		default:
+			s.notFull.Wait()
+		}
+	}
+	if s.closed {
+		return ErrSinkClosed
+	}
+	queued := *e
+	queued.Fields = append([]Field(nil), e.Fields...)
+	s.queue[(s.head+s.count)%len(s.queue)] = &queued
+	s.count++
+	s.notEmpty.Signal()
+	return nil
+}
+
+// run writes queued entries until the sink is closed and drained.
+func (s *AsyncSink) run() {
+	defer close(s.done)
+	s.mu.Lock()
+	for {
+		for s.count == 0 && !s.closed {
+			s.notEmpty.Wait()
+		}
+		if s.count == 0 {
+			s.mu.Unlock()
+			return
+		}
+		e := s.queue[s.head]
+		s.queue[s.head] = nil
+		s.head = (s.head + 1) % len(s.queue)
+		s.count--
+		s.writing = true
+		s.notFull.Signal()
+		s.mu.Unlock()
+
+		if err := s.inner.Write(e); err != nil && s.onError != nil {
+			s.onError(err)
+		}
+
+		s.mu.Lock()
+		s.writing = false
+		if s.count == 0 {
+			s.idle.Broadcast()
+		}
+	}
+}
+
+// Dropped returns how many entries were discarded by the overflow policy.
+func (s *AsyncSink) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Flush blocks until every queued entry has been written, then flushes the wrapped sink.
+func (s *AsyncSink) Flush() error {
+	s.mu.Lock()
+	for s.count > 0 || s.writing {
+		s.idle.Wait()
+	}
+	s.mu.Unlock()
+	if f, ok := s.inner.(Flusher); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+// Close stops accepting entries, drains the queue and stops the background writer.
+// It does not close the wrapped sink.
+func (s *AsyncSink) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	s.notEmpty.Broadcast()
+	s.notFull.Broadcast()
+	s.mu.Unlock()
+	<-s.done
+	if f, ok := s.inner.(Flusher); ok {
+		return f.Flush()
+	}
+	return nil
+}
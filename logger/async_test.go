@@ -0,0 +1,99 @@
+package logger
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+)
+
+// memorySink records entry messages for tests, optionally waiting on gate before each write.
+type memorySink struct {
+	mu       sync.Mutex
+	messages []string
+	gate     chan struct{}
+}
+
+func (s *memorySink) Write(e *Entry) error {
+	if s.gate != nil {
+		<-s.gate
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = append(s.messages, e.Message)
+	return nil
+}
+
+func (s *memorySink) snapshot() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.messages...)
+}
+
+// TestAsyncSinkFlush checks that Flush delivers every queued entry in order.
+func TestAsyncSinkFlush(t *testing.T) {
+	mem := &memorySink{}
+	async := NewAsyncSink(mem, AsyncOptions{Size: 4})
+	defer async.Close()
+	l := New(WithSink(async))
+	for i := 0; i < 50; i++ {
+		l.Info("msg %d", i)
+	}
+	l.Flush()
+	got := mem.snapshot()
+	if len(got) != 50 || got[0] != "msg 0" || got[49] != "msg 49" {
+		t.Errorf("unexpected delivery: %d entries, %v", len(got), got)
+	}
+}
+
+// TestAsyncSinkOverflow checks the drop policies and the dropped counter.
+func TestAsyncSinkOverflow(t *testing.T) {
+	for _, tc := range []struct {
+		policy OverflowPolicy
+		want   []string
+	}{
+		{DropNewest, []string{"0", "1", "2"}},
+		{DropOldest, []string{"0", "3", "4"}},
+	} {
+		mem := &memorySink{gate: make(chan struct{})}
+		async := NewAsyncSink(mem, AsyncOptions{Size: 2, Overflow: tc.policy})
+		async.Write(&Entry{Message: "0"})
+		// Wait until the writer has taken "0" and is blocked on the gate.
+		for {
+			async.mu.Lock()
+			writing := async.writing
+			async.mu.Unlock()
+			if writing {
+				break
+			}
+			runtime.Gosched()
+		}
+		for _, m := range []string{"1", "2", "3", "4"} {
+			async.Write(&Entry{Message: m})
+		}
+		close(mem.gate)
+		async.Close()
+
+		got := mem.snapshot()
+		if len(got) != len(tc.want) {
+			t.Fatalf("policy %d: got %v, want %v", tc.policy, got, tc.want)
+		}
+		for i := range tc.want {
+			if got[i] != tc.want[i] {
+				t.Errorf("policy %d: got %v, want %v", tc.policy, got, tc.want)
+				break
+			}
+		}
+		if async.Dropped() != 2 {
+			t.Errorf("policy %d: dropped %d, want 2", tc.policy, async.Dropped())
+		}
+	}
+}
+
+// TestAsyncSinkClose checks that writes after Close are rejected.
+func TestAsyncSinkClose(t *testing.T) {
+	async := NewAsyncSink(&memorySink{}, AsyncOptions{})
+	async.Close()
+	if err := async.Write(&Entry{Message: "late"}); err != ErrSinkClosed {
+		t.Errorf("expected ErrSinkClosed, got %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+package logger
+
+import (
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Caller describes the call site of a log entry. The zero value means the
+// caller is unknown or lookup is disabled.
+type Caller struct {
+	PC       uintptr
+	File     string
+	Line     int
+	Function string
+}
+
+// Defined reports whether the caller was looked up.
+func (c Caller) Defined() bool {
+	return c.File != ""
+}
+
+// String returns "file.go:42" using the base name of the file, or "???" when undefined.
+func (c Caller) String() string {
+	if !c.Defined() {
+		return "???"
+	}
+	return filepath.Base(c.File) + ":" + strconv.Itoa(c.Line)
+}
+
+// ShortFunction returns the function name without its package path, e.g. "logger.(*Logger).Info".
+func (c Caller) ShortFunction() string {
+	name := c.Function
+	if i := strings.LastIndexByte(name, '/'); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
+
+// callerCache maps program counters to resolved callers, so each call site is
+// symbolized once and later lookups do not allocate.
+var callerCache = struct {
+	sync.RWMutex
+	m map[uintptr]Caller
+}{m: make(map[uintptr]Caller)}
+
+// callerAt looks up the caller skip frames above its own caller.
+// The function name is only included when withFunc is set.
+func callerAt(skip int, withFunc bool) Caller {
+	var pcs [1]uintptr
+	if runtime.Callers(skip+2, pcs[:]) == 0 {
+		return Caller{}
+	}
+	callerCache.RLock()
+	c, ok := callerCache.m[pcs[0]]
+	callerCache.RUnlock()
+	if !ok {
+		c = callerFromPC(pcs[0], true)
+		callerCache.Lock()
+		callerCache.m[pcs[0]] = c
+		callerCache.Unlock()
+	}
+	if !withFunc {
+		c.Function = ""
+	}
+	return c
+}
+
+// callerFromPC resolves a program counter, such as slog.Record.PC, into a Caller.
+func callerFromPC(pc uintptr, withFunc bool) Caller {
+	if pc == 0 {
+		return Caller{}
+	}
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	c := Caller{PC: pc, File: frame.File, Line: frame.Line}
+	if withFunc {
+		c.Function = frame.Function
+	}
+	return c
+}
+
+// WithCaller enables or disables the caller lookup. Disabling it saves a
+// runtime.Callers call per entry.
+func WithCaller(enabled bool) Option {
+	return func(l *Logger) {
+		l.addCaller = enabled
+	}
+}
+
+// WithCallerFunction includes the calling function's name in entries.
+func WithCallerFunction(enabled bool) Option {
+	return func(l *Logger) {
+		l.callerFunc = enabled
+	}
+}
+
+// AddCallerSkip skips n extra stack frames when looking up the caller, so helpers
+// that wrap the logger report their own callers instead of themselves.
+func AddCallerSkip(n int) Option {
+	return func(l *Logger) {
+		l.callerSkip += n
+	}
+}
+
+// WithCallerSkip returns a child logger that skips n extra stack frames when looking up the caller.
+func (l *Logger) WithCallerSkip(n int) *Logger {
+	child := l.clone()
+	child.callerSkip += n
+	return child
+}
@@ -0,0 +1,92 @@
+package logger
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// entrySink keeps the last entry it received.
+type entrySink struct {
+	mu   sync.Mutex
+	last Entry
+}
+
+func (s *entrySink) Write(e *Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.last = *e
+	s.last.Fields = append([]Field(nil), e.Fields...)
+	return nil
+}
+
+// nextLine returns the line number following its call.
+func nextLine() int {
+	_, _, line, _ := runtime.Caller(1)
+	return line + 1
+}
+
+// logHelper wraps a logger the way application helpers do.
+func logHelper(l *Logger, msg string) {
+	l.Info("%s", msg)
+}
+
+// TestCallerLine checks that entries carry the file, line and optional function name.
+func TestCallerLine(t *testing.T) {
+	sink := &entrySink{}
+	l := New(WithSink(sink), WithCallerFunction(true))
+	line := nextLine()
+	l.Info("here")
+
+	c := sink.last.Caller
+	if c.String() != "caller_test.go:"+strconv.Itoa(line) {
+		t.Errorf("caller: got %q, want line %d", c.String(), line)
+	}
+	if !strings.HasSuffix(c.Function, ".TestCallerLine") || c.ShortFunction() != "logger.TestCallerLine" {
+		t.Errorf("function: got %q / %q", c.Function, c.ShortFunction())
+	}
+}
+
+// TestAddCallerSkip checks that wrappers can report their own caller.
+func TestAddCallerSkip(t *testing.T) {
+	sink := &entrySink{}
+	l := New(WithSink(sink), WithCallerFunction(true))
+	logHelper(l, "direct")
+	if !strings.HasSuffix(sink.last.Caller.Function, ".logHelper") {
+		t.Errorf("without skip the helper should be reported, got %q", sink.last.Caller.Function)
+	}
+
+	line := nextLine()
+	logHelper(l.WithCallerSkip(1), "skipped")
+	if sink.last.Caller.Line != line {
+		t.Errorf("with skip the test line %d should be reported, got %q", line, sink.last.Caller.String())
+	}
+
+	line = nextLine()
+	logHelper(New(WithSink(sink), AddCallerSkip(1)), "option")
+	if sink.last.Caller.Line != line {
+		t.Errorf("AddCallerSkip: want line %d, got %q", line, sink.last.Caller.String())
+	}
+}
+
+// TestWithCallerDisabled checks that the caller can be turned off and is omitted by encoders.
+func TestWithCallerDisabled(t *testing.T) {
+	sink := &entrySink{}
+	New(WithSink(sink), WithCaller(false)).Info("fast")
+	if sink.last.Caller.Defined() {
+		t.Errorf("caller should be undefined, got %+v", sink.last.Caller)
+	}
+
+	e := testEntry()
+	e.Caller = Caller{}
+	for _, enc := range []Encoder{TextEncoder{}, JSONEncoder{}, LogfmtEncoder{}} {
+		var buf bytes.Buffer
+		enc.Encode(&buf, e)
+		if strings.Contains(buf.String(), "caller") || strings.Contains(buf.String(), "main.go") {
+			t.Errorf("%T should omit an undefined caller: %q", enc, buf.String())
+		}
+	}
+}
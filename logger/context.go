@@ -0,0 +1,147 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"github.com/isaacwallace123/GoUtils/uuidutil"
+)
+
+// RequestIDKey is the field key used for request IDs taken from a context.
+const RequestIDKey = "request_id"
+
+type contextKey int
+
+const (
+	fieldsKey contextKey = iota
+	requestIDKey
+)
+
+// ContextWithFields returns a copy of ctx carrying the given key/value pairs
+// in addition to any fields already attached to it.
+func ContextWithFields(ctx context.Context, keysAndValues ...interface{}) context.Context {
+	existing, _ := ctx.Value(fieldsKey).([]Field)
+	fields := append(append([]Field(nil), existing...), toFields(keysAndValues)...)
+	return context.WithValue(ctx, fieldsKey, fields)
+}
+
+// ContextWithRequestID returns a copy of ctx carrying the given request ID.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// RequestIDFromContext returns the request ID attached to ctx, or "" if there is none.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// EnsureRequestID returns ctx and its request ID, minting a new UUID when ctx has none.
+func EnsureRequestID(ctx context.Context) (context.Context, string) {
+	if id := RequestIDFromContext(ctx); id != "" {
+		return ctx, id
+	}
+	id := uuidutil.Generate()
+	return ContextWithRequestID(ctx, id), id
+}
+
+// FieldsFromContext returns the request ID and fields attached to ctx.
+func FieldsFromContext(ctx context.Context) []Field {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(fieldsKey).([]Field)
+	id := RequestIDFromContext(ctx)
+	if id == "" {
+		return fields
+	}
+	return append([]Field{String(RequestIDKey, id)}, fields...)
+}
+
+// WithContext returns a child logger that adds the fields attached to ctx to every entry.
+func (l *Logger) WithContext(ctx context.Context) *Logger {
+	child := l.clone()
+	child.fields = append(child.fields, FieldsFromContext(ctx)...)
+	return child
+}
+
+// DebugCtx logs a message at DEBUG level with the fields attached to ctx.
+func (l *Logger) DebugCtx(ctx context.Context, message string, args ...interface{}) {
+	if l.Enabled(LevelDebug) {
+		l.log(LevelDebug, sprintf(message, args...), FieldsFromContext(ctx))
+	}
+}
+
+// InfoCtx logs a message at INFO level with the fields attached to ctx.
+func (l *Logger) InfoCtx(ctx context.Context, message string, args ...interface{}) {
+	if l.Enabled(LevelInfo) {
+		l.log(LevelInfo, sprintf(message, args...), FieldsFromContext(ctx))
+	}
+}
+
+// WarnCtx logs a message at WARN level with the fields attached to ctx.
+func (l *Logger) WarnCtx(ctx context.Context, message string, args ...interface{}) {
+	if l.Enabled(LevelWarn) {
+		l.log(LevelWarn, sprintf(message, args...), FieldsFromContext(ctx))
+	}
+}
+
+// ErrorCtx logs a message at ERROR level with the fields attached to ctx and returns it as a *LoggedError.
+func (l *Logger) ErrorCtx(ctx context.Context, message string, args ...interface{}) error {
+	return l.logError(fmt.Errorf(message, args...), FieldsFromContext(ctx))
+}
+
+// PanicCtx logs a message at PANIC level with the fields attached to ctx and then panics with it.
+func (l *Logger) PanicCtx(ctx context.Context, message string, args ...interface{}) {
+	formatted := sprintf(message, args...)
+	l.log(LevelPanic, formatted, FieldsFromContext(ctx))
+	l.Flush()
+	panic(formatted)
+}
+
+// FatalCtx logs a message with the fields attached to ctx, runs the exit hooks and exits the application.
+func (l *Logger) FatalCtx(ctx context.Context, message string, args ...interface{}) {
+	l.log(LevelFatal, sprintf(message, args...), FieldsFromContext(ctx))
+	l.exit(1)
+}
+
+// DebugCtx logs a message at DEBUG level on the default logger with the fields attached to ctx.
+func DebugCtx(ctx context.Context, message string, args ...interface{}) {
+	if l := Default(); l.Enabled(LevelDebug) {
+		l.log(LevelDebug, sprintf(message, args...), FieldsFromContext(ctx))
+	}
+}
+
+// InfoCtx logs a message at INFO level on the default logger with the fields attached to ctx.
+func InfoCtx(ctx context.Context, message string, args ...interface{}) {
+	if l := Default(); l.Enabled(LevelInfo) {
+		l.log(LevelInfo, sprintf(message, args...), FieldsFromContext(ctx))
+	}
+}
+
+// WarnCtx logs a message at WARN level on the default logger with the fields attached to ctx.
+func WarnCtx(ctx context.Context, message string, args ...interface{}) {
+	if l := Default(); l.Enabled(LevelWarn) {
+		l.log(LevelWarn, sprintf(message, args...), FieldsFromContext(ctx))
+	}
+}
+
+// ErrorCtx logs a message at ERROR level on the default logger with the fields attached to ctx and returns it as a *LoggedError.
+func ErrorCtx(ctx context.Context, message string, args ...interface{}) error {
+	return Default().logError(fmt.Errorf(message, args...), FieldsFromContext(ctx))
+}
+
+// PanicCtx logs a message at PANIC level on the default logger with the fields attached to ctx and then panics with it.
+func PanicCtx(ctx context.Context, message string, args ...interface{}) {
+	l := Default()
+	formatted := sprintf(message, args...)
+	l.log(LevelPanic, formatted, FieldsFromContext(ctx))
+	l.Flush()
+	panic(formatted)
+}
+
+// FatalCtx logs a message on the default logger with the fields attached to ctx, runs the exit hooks and exits the application.
+func FatalCtx(ctx context.Context, message string, args ...interface{}) {
+	l := Default()
+	l.log(LevelFatal, sprintf(message, args...), FieldsFromContext(ctx))
+	l.exit(1)
+}
@@ -0,0 +1,58 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"github.com/isaacwallace123/GoUtils/uuidutil"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// TestInfoCtx checks that request IDs and context fields are added to log lines.
+func TestInfoCtx(t *testing.T) {
+	var out bytes.Buffer
+	l := New(WithOutput(&out), WithEncoder(LogfmtEncoder{}))
+	ctx := ContextWithRequestID(context.Background(), "req-1")
+	ctx = ContextWithFields(ctx, "user", "alice")
+	ctx = ContextWithFields(ctx, "tenant", 9)
+	l.InfoCtx(ctx, "loaded %d items", 3)
+
+	if !strings.Contains(out.String(), `msg="loaded 3 items" request_id=req-1 user=alice tenant=9`) {
+		t.Errorf("context fields missing: %q", out.String())
+	}
+}
+
+// TestEnsureRequestID checks that a request ID is minted once and then reused.
+func TestEnsureRequestID(t *testing.T) {
+	ctx, id := EnsureRequestID(context.Background())
+	if !uuidutil.IsValid(id) {
+		t.Fatalf("minted request ID is not a UUID: %q", id)
+	}
+	if _, again := EnsureRequestID(ctx); again != id {
+		t.Errorf("EnsureRequestID replaced existing ID %q with %q", id, again)
+	}
+	if FieldsFromContext(context.Background()) != nil {
+		t.Error("empty context should have no fields")
+	}
+}
+
+// TestWithContextAndSlog checks WithContext children and slog handlers pick up context fields.
+func TestWithContextAndSlog(t *testing.T) {
+	var out bytes.Buffer
+	l := New(WithOutput(&out), WithEncoder(LogfmtEncoder{}))
+	ctx := ContextWithRequestID(context.Background(), "req-2")
+
+	l.WithContext(ctx).Warnw("retry", "attempt", 1)
+	slog.New(NewSlogHandler(l)).InfoContext(ctx, "via slog")
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %q", out.String())
+	}
+	for _, line := range lines {
+		if !strings.Contains(line, "request_id=req-2") {
+			t.Errorf("request ID missing: %q", line)
+		}
+	}
+}
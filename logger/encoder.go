@@ -0,0 +1,344 @@
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/isaacwallace123/GoUtils/color"
+	"github.com/isaacwallace123/GoUtils/jsonutil"
+	"io"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Entry is a single log event handed to an Encoder.
+type Entry struct {
+	Time    time.Time
+	Level   Level
+	Tag     LogTag
+	Caller  Caller
+	Logger  string
+	Message string
+	Fields  []Field
+	Stack   string
+}
+
+// Encoder serializes an entry into buf. Line-oriented encoders such as TextEncoder,
+// JSONEncoder and LogfmtEncoder include the trailing newline; encoders for framed
+// transports, such as SyslogEncoder, write no newline and leave framing to their sink.
+type Encoder interface {
+	Encode(buf *bytes.Buffer, e *Entry) error
+}
+
+// TextEncoder renders entries in the colored "[timestamp] [LEVEL] [file:line] [name] message k=v" format.
+// The [timestamp] and [file:line] parts are omitted when the time or caller is unknown and
+// [name] is only present for named loggers.
+// A stack trace, if any, follows on the next lines.
+type TextEncoder struct {
+	// NoColor disables the ANSI color codes around the level tag. It is a shorthand for ColorNever.
+	NoColor bool
+	// Color chooses when the level tag is colored. The default, ColorAuto, is resolved
+	// against the output by New, To and NewWriterSink.
+	Color ColorMode
+	// TimeFormat defaults to TimeFormatDateTime.
+	TimeFormat TimeFormat
+}
+
+// ColorMode chooses whether a TextEncoder emits ANSI color codes.
+type ColorMode int
+
+const (
+	// ColorAuto colors output only when it supports colors (see color.ProfileFor).
+	// An encoder used directly, without a writer to check, colors its output.
+	ColorAuto ColorMode = iota
+	// ColorAlways colors output regardless of where it goes.
+	ColorAlways
+	// ColorNever never emits color codes.
+	ColorNever
+)
+
+// colored reports whether the encoder emits color codes.
+func (enc TextEncoder) colored() bool {
+	return !enc.NoColor && enc.Color != ColorNever
+}
+
+// resolveColor returns enc with ColorAuto replaced by the mode w supports, if enc is a TextEncoder.
+func resolveColor(enc Encoder, w io.Writer) Encoder {
+	e, ok := enc.(TextEncoder)
+	if !ok || e.Color != ColorAuto || e.NoColor {
+		return enc
+	}
+	e.Color = ColorAlways
+	if color.ProfileFor(w) == color.ProfileNone {
+		e.Color = ColorNever
+	}
+	return e
+}
+
+// Encode implements Encoder.
+func (enc TextEncoder) Encode(buf *bytes.Buffer, e *Entry) error {
+	if !e.Time.IsZero() {
+		buf.WriteByte('[')
+		buf.Write(enc.TimeFormat.or(TimeFormatDateTime).appendTo(buf.AvailableBuffer(), e.Time))
+		buf.WriteString("] ")
+	}
+	colored := enc.colored()
+	if colored {
+		buf.WriteString(e.Tag.Color)
+	}
+	buf.WriteByte('[')
+	buf.WriteString(e.Tag.Name)
+	buf.WriteByte(']')
+	if colored {
+		buf.WriteString(reset)
+	}
+	buf.WriteByte(' ')
+	if e.Caller.Defined() {
+		buf.WriteByte('[')
+		writeCaller(buf, e.Caller)
+		if e.Caller.Function != "" {
+			buf.WriteByte(' ')
+			buf.WriteString(e.Caller.ShortFunction())
+		}
+		buf.WriteString("] ")
+	}
+	if e.Logger != "" {
+		buf.WriteByte('[')
+		buf.WriteString(e.Logger)
+		buf.WriteString("] ")
+	}
+	buf.WriteString(e.Message)
+	for _, f := range e.Fields {
+		buf.WriteByte(' ')
+		buf.WriteString(f.Key)
+		buf.WriteByte('=')
+		writeFieldValue(buf, f)
+	}
+	buf.WriteByte('\n')
+	if e.Stack != "" {
+		buf.WriteString(e.Stack)
+		buf.WriteByte('\n')
+	}
+	return nil
+}
+
+// writeCaller writes c as "file.go:42" without allocating.
+func writeCaller(buf *bytes.Buffer, c Caller) {
+	buf.WriteString(filepath.Base(c.File))
+	buf.WriteByte(':')
+	buf.Write(strconv.AppendInt(buf.AvailableBuffer(), int64(c.Line), 10))
+}
+
+// writeFieldValue writes the plain form of f's value, quoting strings that contain spaces, quotes or control characters.
+func writeFieldValue(buf *bytes.Buffer, f Field) {
+	switch f.Kind {
+	case AnyKind:
+		writeValue(buf, stringValue(f.Value))
+	case StringKind:
+		writeValue(buf, f.str)
+	default:
+		buf.Write(f.appendScalar(buf.AvailableBuffer()))
+	}
+}
+
+// writeValue writes s bare when possible and as an escaped quoted string otherwise.
+func writeValue(buf *bytes.Buffer, s string) {
+	if !needsQuoting(s) {
+		buf.WriteString(s)
+		return
+	}
+	buf.Write(strconv.AppendQuote(buf.AvailableBuffer(), s))
+}
+
+// writeLower writes the ASCII lowercase form of s.
+func writeLower(buf *bytes.Buffer, s string) {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		buf.WriteByte(c)
+	}
+}
+
+// stringValue converts a field value to its plain string form.
+func stringValue(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	// fmt calls Error and String for us and prints <nil> when they panic on a nil receiver.
+	return fmt.Sprint(v)
+}
+
+// needsQuoting reports whether s must be quoted to stay a single token.
+func needsQuoting[T string | []byte](s T) bool {
+	if len(s) == 0 {
+		return true
+	}
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c <= ' ' || c == '"' || c == '=' || c == 0x7f {
+			return true
+		}
+	}
+	return false
+}
+
+// JSONEncoder renders entries as JSON lines with time, level, caller, func, logger, msg, the entry fields and stack.
+// Epoch time formats are written as numbers and time is left out when the entry has none.
+type JSONEncoder struct {
+	// TimeFormat defaults to TimeFormatRFC3339Nano.
+	TimeFormat TimeFormat
+}
+
+// Encode implements Encoder.
+func (enc JSONEncoder) Encode(buf *bytes.Buffer, e *Entry) error {
+	buf.WriteByte('{')
+	if !e.Time.IsZero() {
+		buf.WriteString(`"time":`)
+		enc.TimeFormat.or(TimeFormatRFC3339Nano).appendJSON(buf, e.Time)
+		buf.WriteByte(',')
+	}
+	buf.WriteString(`"level":`)
+	if jsonSafe(e.Tag.Name) {
+		buf.WriteByte('"')
+		writeLower(buf, e.Tag.Name)
+		buf.WriteByte('"')
+	} else {
+		writeJSONString(buf, strings.ToLower(e.Tag.Name))
+	}
+	if e.Caller.Defined() {
+		buf.WriteString(`,"caller":`)
+		if base := filepath.Base(e.Caller.File); jsonSafe(base) {
+			buf.WriteByte('"')
+			writeCaller(buf, e.Caller)
+			buf.WriteByte('"')
+		} else {
+			writeJSONString(buf, e.Caller.String())
+		}
+		if e.Caller.Function != "" {
+			buf.WriteString(`,"func":`)
+			writeJSONString(buf, e.Caller.Function)
+		}
+	}
+	if e.Logger != "" {
+		buf.WriteString(`,"logger":`)
+		writeJSONString(buf, e.Logger)
+	}
+	buf.WriteString(`,"msg":`)
+	writeJSONString(buf, e.Message)
+	for _, f := range e.Fields {
+		buf.WriteByte(',')
+		writeJSONString(buf, f.Key)
+		buf.WriteByte(':')
+		writeJSONValue(buf, f)
+	}
+	if e.Stack != "" {
+		buf.WriteString(`,"stack":`)
+		writeJSONString(buf, e.Stack)
+	}
+	buf.WriteString("}\n")
+	return nil
+}
+
+// writeJSONValue encodes a field value. Typed fields are written directly; other
+// values are marshaled, falling back to their string form when that fails.
+func writeJSONValue(buf *bytes.Buffer, f Field) {
+	switch f.Kind {
+	case StringKind:
+		writeJSONString(buf, f.str)
+		return
+	case FloatKind:
+		if v := math.Float64frombits(f.num); math.IsNaN(v) || math.IsInf(v, 0) {
+			writeJSONString(buf, strconv.FormatFloat(v, 'g', -1, 64))
+			return
+		}
+	case DurationKind, TimeKind:
+		buf.WriteByte('"')
+		buf.Write(f.appendScalar(buf.AvailableBuffer()))
+		buf.WriteByte('"')
+		return
+	case AnyKind:
+		switch val := f.Value.(type) {
+		case string:
+			writeJSONString(buf, val)
+		case error:
+			writeJSONString(buf, stringValue(val))
+		case time.Duration:
+			writeJSONString(buf, val.String())
+		default:
+			s, err := jsonutil.Encode(val)
+			if err != nil {
+				s = jsonutil.ToString(stringValue(val))
+			}
+			buf.WriteString(s)
+		}
+		return
+	}
+	buf.Write(f.appendScalar(buf.AvailableBuffer()))
+}
+
+// jsonSafe reports whether s can be written between JSON quotes without escaping.
+func jsonSafe[T string | []byte](s T) bool {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c < ' ' || c >= utf8.RuneSelf || c == '"' || c == '\\' || c == '<' || c == '>' || c == '&' {
+			return false
+		}
+	}
+	return true
+}
+
+// writeJSONString writes s as a JSON string, escaping it the way encoding/json does.
+func writeJSONString(buf *bytes.Buffer, s string) {
+	const hex = "0123456789abcdef"
+	buf.WriteByte('"')
+	start := 0
+	for i := 0; i < len(s); {
+		if c := s[i]; c < utf8.RuneSelf {
+			if c >= ' ' && c != '"' && c != '\\' && c != '<' && c != '>' && c != '&' {
+				i++
+				continue
+			}
+			buf.WriteString(s[start:i])
+			switch c {
+			case '"', '\\':
+				buf.WriteByte('\\')
+				buf.WriteByte(c)
+			case '\n':
+				buf.WriteString(`\n`)
+			case '\r':
+				buf.WriteString(`\r`)
+			case '\t':
+				buf.WriteString(`\t`)
+			default:
+				buf.WriteString(`\u00`)
+				buf.WriteByte(hex[c>>4])
+				buf.WriteByte(hex[c&0xf])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			buf.WriteString(s[start:i])
+			buf.WriteString(`\ufffd`)
+			i += size
+			start = i
+			continue
+		}
+		if r == '\u2028' || r == '\u2029' {
+			buf.WriteString(s[start:i])
+			buf.WriteString(`\u202`)
+			buf.WriteByte(hex[r&0xf])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	buf.WriteString(s[start:])
+	buf.WriteByte('"')
+}
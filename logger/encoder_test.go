@@ -0,0 +1,119 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/isaacwallace123/GoUtils/color"
+	"github.com/isaacwallace123/GoUtils/jsonutil"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testEntry returns a fixed entry for encoder tests.
+func testEntry() *Entry {
+	return &Entry{
+		Time:    time.Date(2024, 5, 1, 12, 30, 45, 0, time.UTC),
+		Level:   LevelWarn,
+		Tag:     WarnTag,
+		Caller:  Caller{File: "/src/app/main.go", Line: 42},
+		Message: "disk almost full",
+		Fields:  []Field{F("path", "/var/log"), F("used", 0.93), F("err", errors.New("quota"))},
+	}
+}
+
+// TestTextEncoder checks the colored text line, the NoColor variant and a zero time.
+func TestTextEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	TextEncoder{}.Encode(&buf, testEntry())
+	want := "[2024-05-01 12:30:45] " + color.WarnColor + "[WARN]" + color.Reset + " [main.go:42] disk almost full path=/var/log used=0.93 err=quota\n"
+	if buf.String() != want {
+		t.Errorf("TextEncoder: got %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	TextEncoder{NoColor: true}.Encode(&buf, testEntry())
+	if strings.Contains(buf.String(), "\033[") {
+		t.Errorf("TextEncoder with NoColor emitted ANSI codes: %q", buf.String())
+	}
+
+	buf.Reset()
+	e := testEntry()
+	e.Time = time.Time{}
+	TextEncoder{NoColor: true}.Encode(&buf, e)
+	if !strings.HasPrefix(buf.String(), "[WARN] ") {
+		t.Errorf("TextEncoder should omit a zero time: %q", buf.String())
+	}
+}
+
+// TestJSONEncoder checks that JSON lines are valid and carry the standard keys and fields.
+func TestJSONEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	JSONEncoder{}.Encode(&buf, testEntry())
+	line := buf.String()
+	if !strings.HasSuffix(line, "}\n") || !jsonutil.IsValid(line) {
+		t.Fatalf("JSONEncoder produced invalid JSON: %q", line)
+	}
+	obj := jsonutil.ToObject(line)
+	want := map[string]any{
+		"time":   "2024-05-01T12:30:45Z",
+		"level":  "warn",
+		"caller": "main.go:42",
+		"msg":    "disk almost full",
+		"path":   "/var/log",
+		"used":   0.93,
+		"err":    "quota",
+	}
+	for k, v := range want {
+		if obj[k] != v {
+			t.Errorf("key %q: got %v, want %v", k, obj[k], v)
+		}
+	}
+}
+
+// TestJSONEncoderUnsupportedValue checks that values json cannot marshal still produce valid JSON.
+func TestJSONEncoderUnsupportedValue(t *testing.T) {
+	var out bytes.Buffer
+	l := New(WithOutput(&out), WithEncoder(JSONEncoder{}))
+	l.Infow("odd", "ch", make(chan int))
+	if !jsonutil.IsValid(out.String()) {
+		t.Errorf("expected valid JSON, got %q", out.String())
+	}
+}
+
+// pathError is an error with a pointer receiver, for typed-nil tests.
+type pathError struct{ path string }
+
+func (e *pathError) Error() string { return "bad path " + e.path }
+
+// TestEncodersNilValues checks typed-nil Stringer and error values are printed, not dereferenced.
+func TestEncodersNilValues(t *testing.T) {
+	for _, enc := range []Encoder{TextEncoder{}, JSONEncoder{}, LogfmtEncoder{}, SyslogEncoder{}} {
+		var buf bytes.Buffer
+		l := New(WithOutput(&buf), WithEncoder(enc), WithRedactor(DefaultRedactor()))
+		var err error = (*pathError)(nil)
+		l.Infow("nil values", "u", (*url.URL)(nil), "err", err)
+		out := strings.ReplaceAll(buf.String(), `\u003cnil\u003e`, "<nil>")
+		if !strings.Contains(out, "nil values") || !strings.Contains(out, "<nil>") {
+			t.Errorf("%T: nil values not printed: %q", enc, buf.String())
+		}
+	}
+}
+
+// BenchmarkEncoders measures encoding an entry with typed fields in each built-in encoder.
+func BenchmarkEncoders(b *testing.B) {
+	e := testEntry()
+	e.Fields = []Field{String("path", "/var/log"), Float64("used", 0.93), Int("files", 1200), Duration("age", time.Hour)}
+	for _, enc := range []Encoder{TextEncoder{}, JSONEncoder{}, LogfmtEncoder{}} {
+		b.Run(strings.TrimPrefix(fmt.Sprintf("%T", enc), "logger."), func(b *testing.B) {
+			var buf bytes.Buffer
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				buf.Reset()
+				enc.Encode(&buf, e)
+			}
+		})
+	}
+}
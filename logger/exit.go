@@ -0,0 +1,112 @@
+package logger
+
+import (
+	"context"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+// DefaultExitTimeout is how long Fatal waits for exit hooks before exiting anyway.
+const DefaultExitTimeout = 5 * time.Second
+
+var (
+	exitMu      sync.Mutex
+	exitHooks   []func(context.Context)
+	exitTimeout = DefaultExitTimeout
+	exitFunc    = os.Exit
+)
+
+// RegisterExitHook registers fn to run before Fatal exits the process. Hooks run
+// in reverse registration order, like deferred calls, and share one context that
+// is cancelled when the exit timeout elapses.
+func RegisterExitHook(fn func(ctx context.Context)) {
+	exitMu.Lock()
+	defer exitMu.Unlock()
+	exitHooks = append(exitHooks, fn)
+}
+
+// ClearExitHooks removes every registered exit hook.
+func ClearExitHooks() {
+	exitMu.Lock()
+	defer exitMu.Unlock()
+	exitHooks = nil
+}
+
+// SetExitTimeout sets how long Fatal waits for the exit hooks to finish.
+func SetExitTimeout(d time.Duration) {
+	exitMu.Lock()
+	defer exitMu.Unlock()
+	exitTimeout = d
+}
+
+// WithExitFunc replaces the function the logger's Fatal calls after running the exit hooks.
+// Tests can use it to observe Fatal without ending the process.
+func WithExitFunc(fn func(code int)) Option {
+	return func(l *Logger) {
+		l.exitFunc = fn
+	}
+}
+
+// SetExitFunc replaces os.Exit for every logger that has no WithExitFunc option
+// and for Exit. It returns the previous function so tests can restore it.
+func SetExitFunc(fn func(code int)) (previous func(code int)) {
+	exitMu.Lock()
+	defer exitMu.Unlock()
+	previous = exitFunc
+	exitFunc = fn
+	return previous
+}
+
+// currentExitFunc returns the function set with SetExitFunc.
+func currentExitFunc() func(code int) {
+	exitMu.Lock()
+	defer exitMu.Unlock()
+	return exitFunc
+}
+
+// runExitHooks runs the registered hooks and returns once they finish or the timeout elapses.
+func runExitHooks() {
+	exitMu.Lock()
+	hooks := slices.Clone(exitHooks)
+	timeout := exitTimeout
+	exitMu.Unlock()
+	if len(hooks) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := len(hooks) - 1; i >= 0; i-- {
+			if ctx.Err() != nil {
+				return
+			}
+			hooks[i](ctx)
+		}
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+}
+
+// exit flushes the logger, runs the exit hooks and calls the exit function.
+func (l *Logger) exit(code int) {
+	l.Flush()
+	runExitHooks()
+	if l.exitFunc != nil {
+		l.exitFunc(code)
+		return
+	}
+	currentExitFunc()(code)
+}
+
+// Exit flushes the default logger, runs the exit hooks and exits the process with code.
+// Use it instead of os.Exit so hooks and buffered log output are not lost.
+func Exit(code int) {
+	Default().exit(code)
+}
@@ -0,0 +1,67 @@
+package logger
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestFatalRunsHooks checks that Fatal logs, flushes, runs hooks in reverse order and calls the exit function.
+func TestFatalRunsHooks(t *testing.T) {
+	defer ClearExitHooks()
+	var order []string
+	RegisterExitHook(func(context.Context) { order = append(order, "first") })
+	RegisterExitHook(func(context.Context) { order = append(order, "second") })
+
+	mem := &memorySink{}
+	async := NewAsyncSink(mem, AsyncOptions{})
+	defer async.Close()
+	code := -1
+	l := New(WithSink(async), WithExitFunc(func(c int) { code = c }))
+	l.Fatalw("shutting down", "reason", "test")
+
+	if code != 1 {
+		t.Errorf("exit function should get code 1, got %d", code)
+	}
+	if got := mem.snapshot(); len(got) != 1 || got[0] != "shutting down" {
+		t.Errorf("buffered entry was not flushed before exit: %v", got)
+	}
+	if len(order) != 2 || order[0] != "second" || order[1] != "first" {
+		t.Errorf("hooks should run in reverse order, got %v", order)
+	}
+}
+
+// TestExitHookTimeout checks that a stuck hook does not block exit past the timeout.
+func TestExitHookTimeout(t *testing.T) {
+	defer ClearExitHooks()
+	defer SetExitTimeout(DefaultExitTimeout)
+	SetExitTimeout(20 * time.Millisecond)
+	release := make(chan struct{})
+	defer close(release)
+	RegisterExitHook(func(ctx context.Context) { <-release })
+
+	exited := false
+	previous := SetExitFunc(func(int) { exited = true })
+	defer SetExitFunc(previous)
+
+	start := time.Now()
+	New(WithSink(&memorySink{})).Fatal("stuck")
+	if !exited || time.Since(start) > time.Second {
+		t.Errorf("exit should happen after the timeout (exited=%v, took %v)", exited, time.Since(start))
+	}
+}
+
+// TestPanicLevel checks that Panic logs at PANIC level and panics with the message.
+func TestPanicLevel(t *testing.T) {
+	sink := &entrySink{}
+	l := New(WithSink(sink))
+	defer func() {
+		if r := recover(); r != "bad state 7" {
+			t.Errorf("expected panic with message, got %v", r)
+		}
+		if sink.last.Level != LevelPanic || sink.last.Tag.Name != "PANIC" {
+			t.Errorf("unexpected entry: %+v", sink.last)
+		}
+	}()
+	l.Panic("bad state %d", 7)
+}
@@ -0,0 +1,61 @@
+package logger
+
+import (
+	"errors"
+	"io"
+)
+
+// Destination is one target of a fan-out sink: entries below MinLevel are skipped.
+type Destination struct {
+	MinLevel Level
+	Sink     Sink
+}
+
+// To builds a Destination that encodes entries at or above min with enc and writes them to w.
+// A TextEncoder left at ColorAuto only colors w if it supports colors.
+func To(min Level, w io.Writer, enc Encoder) Destination {
+	return Destination{MinLevel: min, Sink: NewWriterSink(w, enc)}
+}
+
+// fanOutSink dispatches each entry to every destination whose level it meets.
+type fanOutSink struct {
+	dests []Destination
+}
+
+// NewFanOutSink returns a Sink that sends each entry to every matching destination.
+// The logger's own level still applies first, so set it to the lowest destination level:
+//
+//	logger.New(logger.WithLevel(logger.LevelDebug), logger.WithSink(logger.NewFanOutSink(
+//		logger.To(logger.LevelDebug, file, logger.JSONEncoder{}),
+//		logger.To(logger.LevelWarn, os.Stderr, logger.TextEncoder{}),
+//	)))
+func NewFanOutSink(dests ...Destination) Sink {
+	return &fanOutSink{dests: append([]Destination(nil), dests...)}
+}
+
+// Write implements Sink. Every matching destination is written even if an earlier one fails.
+func (s *fanOutSink) Write(e *Entry) error {
+	var errs []error
+	for _, d := range s.dests {
+		if e.Level < d.MinLevel {
+			continue
+		}
+		if err := d.Sink.Write(e); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Flush flushes every destination that buffers entries.
+func (s *fanOutSink) Flush() error {
+	var errs []error
+	for _, d := range s.dests {
+		if f, ok := d.Sink.(Flusher); ok {
+			if err := f.Flush(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,45 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// failingSink always returns an error.
+type failingSink struct{}
+
+func (failingSink) Write(*Entry) error { return errors.New("sink down") }
+
+// TestFanOutSink checks that each destination only receives entries at or above its level.
+func TestFanOutSink(t *testing.T) {
+	var file, stderr bytes.Buffer
+	l := New(WithLevel(LevelDebug), WithSink(NewFanOutSink(
+		To(LevelDebug, &file, JSONEncoder{}),
+		To(LevelWarn, &stderr, TextEncoder{NoColor: true}),
+	)))
+	l.Debug("cache miss")
+	l.Info("request")
+	l.Warn("slow query")
+
+	if n := strings.Count(file.String(), "\n"); n != 3 {
+		t.Errorf("file destination should get 3 lines, got %d: %q", n, file.String())
+	}
+	if n := strings.Count(stderr.String(), "\n"); n != 1 || !strings.Contains(stderr.String(), "[WARN]") {
+		t.Errorf("stderr destination should get only the warning, got %q", stderr.String())
+	}
+}
+
+// TestFanOutSinkErrors checks that a failing destination does not stop the others.
+func TestFanOutSinkErrors(t *testing.T) {
+	var out bytes.Buffer
+	sink := NewFanOutSink(Destination{LevelInfo, failingSink{}}, To(LevelInfo, &out, LogfmtEncoder{}))
+	err := sink.Write(&Entry{Level: LevelError, Tag: ErrorTag, Message: "boom"})
+	if err == nil || !strings.Contains(err.Error(), "sink down") {
+		t.Errorf("expected joined error, got %v", err)
+	}
+	if !strings.Contains(out.String(), "msg=boom") {
+		t.Errorf("second destination was skipped: %q", out.String())
+	}
+}
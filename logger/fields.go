@@ -0,0 +1,325 @@
+package logger
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"time"
+)
+
+// FieldKind tells which member of a Field holds its value.
+type FieldKind uint8
+
+const (
+	// AnyKind fields keep their value in Field.Value.
+	AnyKind FieldKind = iota
+	StringKind
+	IntKind
+	UintKind
+	FloatKind
+	BoolKind
+	DurationKind
+	TimeKind
+)
+
+// Field is a structured key/value pair attached to a log entry.
+// Fields built with F or Any have AnyKind and keep their value in Value; the typed
+// constructors such as String and Int store it unboxed, so building and encoding
+// them does not allocate. Use the Any method to read the value of any field.
+type Field struct {
+	Key string
+	// Value holds the value of AnyKind fields only; it is nil for typed fields.
+	Value interface{}
+	Kind  FieldKind
+	num   uint64
+	str   string
+	loc   *time.Location
+}
+
+// badKey is used for a value that has no key, e.g. an odd trailing argument.
+const badKey = "!BADKEY"
+
+// F creates a Field from a key and value.
+func F(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Any is an alias for F.
+func Any(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
+// String creates a string Field.
+func String(key, value string) Field {
+	return Field{Key: key, Kind: StringKind, str: value}
+}
+
+// Int creates an integer Field.
+func Int(key string, value int) Field {
+	return Field{Key: key, Kind: IntKind, num: uint64(value)}
+}
+
+// Int64 creates an integer Field.
+func Int64(key string, value int64) Field {
+	return Field{Key: key, Kind: IntKind, num: uint64(value)}
+}
+
+// Uint64 creates an unsigned integer Field.
+func Uint64(key string, value uint64) Field {
+	return Field{Key: key, Kind: UintKind, num: value}
+}
+
+// Float64 creates a floating-point Field.
+func Float64(key string, value float64) Field {
+	return Field{Key: key, Kind: FloatKind, num: math.Float64bits(value)}
+}
+
+// Bool creates a boolean Field.
+func Bool(key string, value bool) Field {
+	var n uint64
+	if value {
+		n = 1
+	}
+	return Field{Key: key, Kind: BoolKind, num: n}
+}
+
+// Duration creates a time.Duration Field.
+func Duration(key string, value time.Duration) Field {
+	return Field{Key: key, Kind: DurationKind, num: uint64(value)}
+}
+
+// Time creates a time.Time Field. Times outside the range of UnixNano are stored boxed.
+func Time(key string, value time.Time) Field {
+	if value.Before(minUnixNano) || value.After(maxUnixNano) {
+		return Field{Key: key, Value: value}
+	}
+	return Field{Key: key, Kind: TimeKind, num: uint64(value.UnixNano()), loc: value.Location()}
+}
+
+// minUnixNano and maxUnixNano bound the times that fit in an int64 of nanoseconds.
+var (
+	minUnixNano = time.Unix(0, math.MinInt64)
+	maxUnixNano = time.Unix(0, math.MaxInt64)
+)
+
+// Err creates an "error" Field holding err.
+func Err(err error) Field {
+	return Field{Key: "error", Value: err}
+}
+
+// Any returns the field's value, boxing typed values.
+func (f Field) Any() interface{} {
+	switch f.Kind {
+	case StringKind:
+		return f.str
+	case IntKind:
+		return int64(f.num)
+	case UintKind:
+		return f.num
+	case FloatKind:
+		return math.Float64frombits(f.num)
+	case BoolKind:
+		return f.num == 1
+	case DurationKind:
+		return time.Duration(f.num)
+	case TimeKind:
+		return f.time()
+	}
+	return f.Value
+}
+
+// time returns the value of a TimeKind field.
+func (f Field) time() time.Time {
+	t := time.Unix(0, int64(f.num))
+	if f.loc != nil {
+		t = t.In(f.loc)
+	}
+	return t
+}
+
+// appendScalar appends the plain form of a typed, non-string field to dst.
+func (f Field) appendScalar(dst []byte) []byte {
+	switch f.Kind {
+	case IntKind:
+		return strconv.AppendInt(dst, int64(f.num), 10)
+	case UintKind:
+		return strconv.AppendUint(dst, f.num, 10)
+	case FloatKind:
+		return strconv.AppendFloat(dst, math.Float64frombits(f.num), 'g', -1, 64)
+	case BoolKind:
+		return strconv.AppendBool(dst, f.num == 1)
+	case DurationKind:
+		return append(dst, time.Duration(f.num).String()...)
+	case TimeKind:
+		return f.time().AppendFormat(dst, time.RFC3339Nano)
+	}
+	return append(dst, stringValue(f.Any())...)
+}
+
+// plainString returns the field's value in the plain form used by the text encoders.
+func (f Field) plainString() string {
+	switch f.Kind {
+	case AnyKind:
+		return stringValue(f.Value)
+	case StringKind:
+		return f.str
+	}
+	return string(f.appendScalar(nil))
+}
+
+// toFields converts alternating keys and values into fields.
+// Field values may be passed directly and take up a single argument.
+func toFields(keysAndValues []interface{}) []Field {
+	if len(keysAndValues) == 0 {
+		return nil
+	}
+	fields := make([]Field, 0, (len(keysAndValues)+1)/2)
+	for i := 0; i < len(keysAndValues); i++ {
+		switch k := keysAndValues[i].(type) {
+		case Field:
+			fields = append(fields, k)
+		case string:
+			if i+1 >= len(keysAndValues) {
+				fields = append(fields, Field{Key: badKey, Value: k})
+				continue
+			}
+			fields = append(fields, Field{Key: k, Value: keysAndValues[i+1]})
+			i++
+		default:
+			fields = append(fields, Field{Key: badKey, Value: k})
+		}
+	}
+	return fields
+}
+
+// With returns a child logger that adds the given key/value pairs to every entry.
+// The child shares its parent's output.
+func (l *Logger) With(keysAndValues ...interface{}) *Logger {
+	child := l.clone()
+	child.fields = append(child.fields, toFields(keysAndValues)...)
+	return child
+}
+
+// clone returns a shallow copy of l with its own field slice.
+func (l *Logger) clone() *Logger {
+	c := *l
+	c.fields = append([]Field(nil), l.fields...)
+	return &c
+}
+
+// Debugw logs a message with key/value pairs at DEBUG level.
+func (l *Logger) Debugw(message string, keysAndValues ...interface{}) {
+	if l.Enabled(LevelDebug) {
+		l.log(LevelDebug, message, toFields(keysAndValues))
+	}
+}
+
+// Infow logs a message with key/value pairs at INFO level.
+func (l *Logger) Infow(message string, keysAndValues ...interface{}) {
+	if l.Enabled(LevelInfo) {
+		l.log(LevelInfo, message, toFields(keysAndValues))
+	}
+}
+
+// Warnw logs a message with key/value pairs at WARN level.
+func (l *Logger) Warnw(message string, keysAndValues ...interface{}) {
+	if l.Enabled(LevelWarn) {
+		l.log(LevelWarn, message, toFields(keysAndValues))
+	}
+}
+
+// Errorw logs a message with key/value pairs at ERROR level and returns it as a *LoggedError.
+// Error values among the pairs are treated as causes of the returned error.
+func (l *Logger) Errorw(message string, keysAndValues ...interface{}) error {
+	return l.logError(errors.New(message), toFields(keysAndValues))
+}
+
+// Panicw logs a message with key/value pairs at PANIC level and then panics with it.
+func (l *Logger) Panicw(message string, keysAndValues ...interface{}) {
+	l.log(LevelPanic, message, toFields(keysAndValues))
+	l.Flush()
+	panic(message)
+}
+
+// Fatalw logs a message with key/value pairs, runs the exit hooks and exits the application.
+func (l *Logger) Fatalw(message string, keysAndValues ...interface{}) {
+	l.log(LevelFatal, message, toFields(keysAndValues))
+	l.exit(1)
+}
+
+// Logw logs a message with key/value pairs at level, which may be a custom level.
+// Unlike Panicw and Fatalw, it never panics or exits.
+func (l *Logger) Logw(level Level, message string, keysAndValues ...interface{}) {
+	if l.Enabled(level) {
+		l.log(level, message, toFields(keysAndValues))
+	}
+}
+
+// LogFields logs a message with fields at level. Built with the typed constructors
+// such as String and Int, the fields are neither boxed nor copied to the heap.
+// Like Logw, it never panics or exits.
+func (l *Logger) LogFields(level Level, message string, fields ...Field) {
+	if l.Enabled(level) {
+		l.log(level, message, fields)
+	}
+}
+
+// With returns a child of the default logger that adds the given key/value pairs to every entry.
+func With(keysAndValues ...interface{}) *Logger {
+	return Default().With(keysAndValues...)
+}
+
+// Debugw logs a message with key/value pairs at DEBUG level on the default logger.
+func Debugw(message string, keysAndValues ...interface{}) {
+	if l := Default(); l.Enabled(LevelDebug) {
+		l.log(LevelDebug, message, toFields(keysAndValues))
+	}
+}
+
+// Infow logs a message with key/value pairs at INFO level on the default logger.
+func Infow(message string, keysAndValues ...interface{}) {
+	if l := Default(); l.Enabled(LevelInfo) {
+		l.log(LevelInfo, message, toFields(keysAndValues))
+	}
+}
+
+// Warnw logs a message with key/value pairs at WARN level on the default logger.
+func Warnw(message string, keysAndValues ...interface{}) {
+	if l := Default(); l.Enabled(LevelWarn) {
+		l.log(LevelWarn, message, toFields(keysAndValues))
+	}
+}
+
+// Errorw logs a message with key/value pairs at ERROR level on the default logger and returns it as a *LoggedError.
+func Errorw(message string, keysAndValues ...interface{}) error {
+	return Default().logError(errors.New(message), toFields(keysAndValues))
+}
+
+// Panicw logs a message with key/value pairs at PANIC level on the default logger and then panics with it.
+func Panicw(message string, keysAndValues ...interface{}) {
+	l := Default()
+	l.log(LevelPanic, message, toFields(keysAndValues))
+	l.Flush()
+	panic(message)
+}
+
+// Fatalw logs a message with key/value pairs on the default logger, runs the exit hooks and exits the application.
+func Fatalw(message string, keysAndValues ...interface{}) {
+	l := Default()
+	l.log(LevelFatal, message, toFields(keysAndValues))
+	l.exit(1)
+}
+
+// Logw logs a message with key/value pairs at level on the default logger.
+func Logw(level Level, message string, keysAndValues ...interface{}) {
+	if l := Default(); l.Enabled(level) {
+		l.log(level, message, toFields(keysAndValues))
+	}
+}
+
+// LogFields logs a message with fields at level on the default logger.
+func LogFields(level Level, message string, fields ...Field) {
+	if l := Default(); l.Enabled(level) {
+		l.log(level, message, fields)
+	}
+}
@@ -0,0 +1,98 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestInfow checks that key/value pairs are rendered after the message.
+func TestInfow(t *testing.T) {
+	var out bytes.Buffer
+	l := New(WithOutput(&out))
+	l.Infow("user login", "user_id", 42, "name", "Jane Doe")
+	line := out.String()
+	if !strings.Contains(line, "user login user_id=42 name=\"Jane Doe\"\n") {
+		t.Errorf("fields not rendered after message: %q", line)
+	}
+}
+
+// TestWith checks that child loggers carry persistent fields without changing the parent.
+func TestWith(t *testing.T) {
+	var out bytes.Buffer
+	parent := New(WithOutput(&out))
+	child := parent.With("service", "billing")
+	child.Warnw("retrying", "attempt", 2)
+	parent.Info("plain")
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %q", out.String())
+	}
+	if !strings.HasSuffix(lines[0], "retrying service=billing attempt=2") {
+		t.Errorf("child fields missing: %q", lines[0])
+	}
+	if strings.Contains(lines[1], "service=") {
+		t.Errorf("parent picked up child fields: %q", lines[1])
+	}
+}
+
+// TestToFieldsOddArgs checks that malformed key/value lists do not drop values.
+func TestToFieldsOddArgs(t *testing.T) {
+	fields := toFields([]interface{}{"a", 1, F("b", 2), 3, "dangling"})
+	want := []Field{F("a", 1), F("b", 2), F(badKey, 3), F(badKey, "dangling")}
+	if len(fields) != len(want) {
+		t.Fatalf("got %v, want %v", fields, want)
+	}
+	for i := range want {
+		if fields[i] != want[i] {
+			t.Errorf("field %d: got %v, want %v", i, fields[i], want[i])
+		}
+	}
+}
+
+// TestTypedFields checks typed fields report their values and render the same in every encoder.
+func TestTypedFields(t *testing.T) {
+	ts := time.Date(2024, 5, 1, 12, 30, 45, 0, time.UTC)
+	fields := []Field{
+		String("s", "a b"), Int("i", -3), Uint64("u", 7), Float64("f", 0.5),
+		Bool("b", true), Duration("d", 1500*time.Millisecond), Time("t", ts), Err(errors.New("boom")),
+	}
+	want := []interface{}{"a b", int64(-3), uint64(7), 0.5, true, 1500 * time.Millisecond, ts}
+	for i, f := range fields[:len(want)] {
+		if got := f.Any(); got != want[i] && !(f.Kind == TimeKind && got.(time.Time).Equal(ts)) {
+			t.Errorf("%s: Any() = %#v, want %#v", f.Key, got, want[i])
+		}
+		if f.Value != nil {
+			t.Errorf("%s: typed field should leave Value nil, got %#v", f.Key, f.Value)
+		}
+	}
+
+	e := testEntry()
+	e.Fields = fields
+	var buf bytes.Buffer
+	TextEncoder{NoColor: true}.Encode(&buf, e)
+	if want := `s="a b" i=-3 u=7 f=0.5 b=true d=1.5s t=2024-05-01T12:30:45Z error=boom` + "\n"; !strings.HasSuffix(buf.String(), want) {
+		t.Errorf("text: %q", buf.String())
+	}
+
+	buf.Reset()
+	JSONEncoder{}.Encode(&buf, e)
+	if want := `"s":"a b","i":-3,"u":7,"f":0.5,"b":true,"d":"1.5s","t":"2024-05-01T12:30:45Z","error":"boom"}` + "\n"; !strings.HasSuffix(buf.String(), want) {
+		t.Errorf("json: %q", buf.String())
+	}
+}
+
+// TestLogFields checks typed fields combine with persistent fields and redaction.
+func TestLogFields(t *testing.T) {
+	sink := &entrySink{}
+	l := New(WithSink(sink), WithRedactor(DefaultRedactor())).With("service", "api")
+	l.LogFields(LevelInfo, "login", String("user", "ada"), String("password", "hunter2"), Int("attempt", 2))
+
+	got := sink.last.Fields
+	if len(got) != 4 || got[0].Key != "service" || got[1].Any() != "ada" || got[2].Any() != RedactedValue || got[3].Any() != int64(2) {
+		t.Errorf("fields: %v", got)
+	}
+}
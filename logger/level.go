@@ -0,0 +1,222 @@
+package logger
+
+import (
+	"fmt"
+	"github.com/isaacwallace123/GoUtils/jsonutil"
+	"github.com/isaacwallace123/GoUtils/stringutil"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// registry holds the tag of every known level, built-in and custom.
+var registry = struct {
+	sync.RWMutex
+	tags map[Level]LogTag
+}{tags: map[Level]LogTag{
+	LevelDebug: DebugTag,
+	LevelInfo:  InfoTag,
+	LevelWarn:  WarnTag,
+	LevelError: ErrorTag,
+	LevelPanic: PanicTag,
+	LevelFatal: FatalTag,
+}}
+
+// RegisterLevel adds a custom level such as TRACE (below LevelDebug) or NOTICE
+// (between LevelInfo and LevelWarn) with its own tag. Once registered, the level
+// can be logged with Log and Logw, parsed by ParseLevel and set through the
+// AtomicLevel HTTP endpoint. Both the level and the tag name must be unused.
+func RegisterLevel(level Level, tag LogTag) error {
+	name := stringutil.TrimSpace(tag.Name)
+	if name == "" || strings.ContainsAny(name, " +-") {
+		return fmt.Errorf("logger: invalid level name %q", tag.Name)
+	}
+	tag.Name = name
+	registry.Lock()
+	defer registry.Unlock()
+	if existing, ok := registry.tags[level]; ok {
+		return fmt.Errorf("logger: level %d is already registered as %s", int(level), existing.Name)
+	}
+	for other, t := range registry.tags {
+		if strings.EqualFold(t.Name, name) {
+			return fmt.Errorf("logger: level name %s is already registered for level %d", name, int(other))
+		}
+	}
+	registry.tags[level] = tag
+	return nil
+}
+
+// MustRegisterLevel is like RegisterLevel but panics on error.
+// It is intended for package-level variable initialization.
+func MustRegisterLevel(level Level, tag LogTag) Level {
+	if err := RegisterLevel(level, tag); err != nil {
+		panic(err)
+	}
+	return level
+}
+
+// Levels returns every registered level in ascending order.
+func Levels() []Level {
+	registry.RLock()
+	defer registry.RUnlock()
+	levels := make([]Level, 0, len(registry.tags))
+	for level := range registry.tags {
+		levels = append(levels, level)
+	}
+	slices.Sort(levels)
+	return levels
+}
+
+// LevelTag returns the registered tag for level.
+// Unregistered levels get the name of the nearest lower level plus an offset, e.g. "INFO+2".
+func LevelTag(level Level) LogTag {
+	return levelTag(level)
+}
+
+// levelTag looks up the tag for level in the registry.
+func levelTag(level Level) LogTag {
+	registry.RLock()
+	defer registry.RUnlock()
+	if t, ok := registry.tags[level]; ok {
+		return t
+	}
+	base, ok := nearestLevel(level)
+	if !ok {
+		return LogTag{Name: fmt.Sprintf("LEVEL(%d)", int(level))}
+	}
+	t := registry.tags[base]
+	return LogTag{Name: fmt.Sprintf("%s%+d", t.Name, int(level-base)), Color: t.Color}
+}
+
+// nearestLevel returns the highest registered level at or below level,
+// or the lowest registered level if level is below all of them. registry must be locked.
+func nearestLevel(level Level) (Level, bool) {
+	var base, lowest Level
+	found, have := false, false
+	for l := range registry.tags {
+		if !have || l < lowest {
+			lowest, have = l, true
+		}
+		if l <= level && (!found || l > base) {
+			base, found = l, true
+		}
+	}
+	if !found {
+		return lowest, have
+	}
+	return base, true
+}
+
+// String returns the lowercase name of the level, e.g. "info" or "info+2" for an unregistered level.
+func (l Level) String() string {
+	return strings.ToLower(levelTag(l).Name)
+}
+
+// ParseLevel parses a level name such as "debug", "WARN" or a registered custom level name.
+// An offset from a named level, as produced by Level.String, is also accepted, e.g. "info+2".
+func ParseLevel(s string) (Level, error) {
+	name := stringutil.TrimSpace(s)
+	offset := 0
+	if i := strings.IndexAny(name, "+-"); i > 0 {
+		n, err := strconv.Atoi(name[i:])
+		if err != nil {
+			return 0, fmt.Errorf("logger: unknown level %q", s)
+		}
+		name, offset = name[:i], n
+	}
+	if strings.EqualFold(name, "warning") {
+		name = "warn"
+	}
+	registry.RLock()
+	defer registry.RUnlock()
+	for level, t := range registry.tags {
+		if strings.EqualFold(t.Name, name) {
+			return level + Level(offset), nil
+		}
+	}
+	return 0, fmt.Errorf("logger: unknown level %q", s)
+}
+
+// AtomicLevel is a Level that can be read and changed safely while logging.
+// It is also an http.Handler: GET returns {"level":"info"} and PUT accepts the same body.
+type AtomicLevel struct {
+	v atomic.Int64
+}
+
+// NewAtomicLevel returns an AtomicLevel set to level.
+func NewAtomicLevel(level Level) *AtomicLevel {
+	a := &AtomicLevel{}
+	a.SetLevel(level)
+	return a
+}
+
+// Level returns the current level.
+func (a *AtomicLevel) Level() Level {
+	return Level(a.v.Load())
+}
+
+// SetLevel changes the current level.
+func (a *AtomicLevel) SetLevel(level Level) {
+	a.v.Store(int64(level))
+}
+
+// levelPayload is the JSON body used by ServeHTTP.
+type levelPayload struct {
+	Level string `json:"level,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// ServeHTTP reports the current level on GET and changes it on PUT.
+func (a *AtomicLevel) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		body, err := io.ReadAll(io.LimitReader(r.Body, 1024))
+		if err != nil {
+			writeLevelResponse(w, http.StatusBadRequest, levelPayload{Error: err.Error()})
+			return
+		}
+		var req levelPayload
+		if err := jsonutil.FromBytes(body, &req); err != nil {
+			writeLevelResponse(w, http.StatusBadRequest, levelPayload{Error: "invalid JSON body"})
+			return
+		}
+		level, err := ParseLevel(req.Level)
+		if err != nil {
+			writeLevelResponse(w, http.StatusBadRequest, levelPayload{Error: err.Error()})
+			return
+		}
+		a.SetLevel(level)
+	default:
+		w.Header().Set("Allow", "GET, PUT")
+		writeLevelResponse(w, http.StatusMethodNotAllowed, levelPayload{Error: "method not allowed"})
+		return
+	}
+	writeLevelResponse(w, http.StatusOK, levelPayload{Level: a.Level().String()})
+}
+
+// writeLevelResponse writes payload as the JSON response body.
+func writeLevelResponse(w http.ResponseWriter, status int, payload levelPayload) {
+	w.WriteHeader(status)
+	io.WriteString(w, jsonutil.ToString(payload)+"\n")
+}
+
+// stepLevel moves level delta registered levels up or down, staying within the lowest and highest registered level.
+func stepLevel(level Level, delta int) Level {
+	levels := Levels()
+	i, found := slices.BinarySearch(levels, level)
+	switch {
+	case delta < 0:
+		i += delta
+	case found:
+		i += delta
+	default:
+		i += delta - 1
+	}
+	return levels[max(0, min(i, len(levels)-1))]
+}
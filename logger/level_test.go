@@ -0,0 +1,154 @@
+package logger
+
+import (
+	"bytes"
+	"github.com/isaacwallace123/GoUtils/color"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// TestParseLevel checks level names round-trip and unknown names fail.
+func TestParseLevel(t *testing.T) {
+	for _, level := range []Level{LevelDebug, LevelInfo, LevelWarn, LevelError, LevelFatal} {
+		if got, err := ParseLevel(strings.ToUpper(level.String())); err != nil || got != level {
+			t.Errorf("ParseLevel(%q) = %v, %v", level.String(), got, err)
+		}
+	}
+	if _, err := ParseLevel("loud"); err == nil {
+		t.Error("ParseLevel should reject unknown names")
+	}
+}
+
+// TestAtomicLevelConcurrent changes the level while logging to check for data races.
+func TestAtomicLevelConcurrent(t *testing.T) {
+	l := New(WithSink(&memorySink{}))
+	child := l.With("k", "v")
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			l.SetLevel(Level(i % 4))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			child.Info("tick")
+		}
+	}()
+	wg.Wait()
+	l.SetLevel(LevelError)
+	if child.GetLevel() != LevelError {
+		t.Error("child logger should share its parent's level")
+	}
+}
+
+// TestAtomicLevelHTTP checks GET, PUT and error responses of the level handler.
+func TestAtomicLevelHTTP(t *testing.T) {
+	a := NewAtomicLevel(LevelInfo)
+
+	rec := httptest.NewRecorder()
+	a.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/level", nil))
+	if rec.Code != http.StatusOK || strings.TrimSpace(rec.Body.String()) != `{"level":"info"}` {
+		t.Errorf("GET: %d %q", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	a.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/level", strings.NewReader(`{"level":"debug"}`)))
+	if rec.Code != http.StatusOK || a.Level() != LevelDebug {
+		t.Errorf("PUT: %d %q, level %v", rec.Code, rec.Body.String(), a.Level())
+	}
+
+	rec = httptest.NewRecorder()
+	a.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/level", strings.NewReader(`{"level":"loud"}`)))
+	if rec.Code != http.StatusBadRequest || a.Level() != LevelDebug {
+		t.Errorf("bad PUT: %d %q", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	a.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/level", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST: %d", rec.Code)
+	}
+}
+
+// registerTestLevel registers a custom level for the duration of the test.
+func registerTestLevel(t *testing.T, level Level, name string) {
+	t.Helper()
+	if err := RegisterLevel(level, LogTag{name, color.Blue}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		registry.Lock()
+		delete(registry.tags, level)
+		registry.Unlock()
+	})
+}
+
+// TestRegisterLevel checks custom levels are named, parsed and rejected when they clash.
+func TestRegisterLevel(t *testing.T) {
+	const levelTrace, levelNotice = LevelDebug - 4, LevelInfo + 2
+	registerTestLevel(t, levelTrace, "TRACE")
+	registerTestLevel(t, levelNotice, "NOTICE")
+
+	if levelNotice.String() != "notice" || LevelTag(levelTrace).Name != "TRACE" {
+		t.Errorf("names: %q %q", levelNotice.String(), LevelTag(levelTrace).Name)
+	}
+	if got, err := ParseLevel("Notice"); err != nil || got != levelNotice {
+		t.Errorf("ParseLevel(Notice) = %v, %v", got, err)
+	}
+	if got := (LevelInfo + 1).String(); got != "info+1" {
+		t.Errorf("unregistered level should be named after the level below it, got %q", got)
+	}
+	if got, err := ParseLevel("info+1"); err != nil || got != LevelInfo+1 {
+		t.Errorf("ParseLevel(info+1) = %v, %v", got, err)
+	}
+	if err := RegisterLevel(levelNotice, LogTag{Name: "OTHER"}); err == nil {
+		t.Error("RegisterLevel should reject a taken level")
+	}
+	if err := RegisterLevel(LevelInfo+3, LogTag{Name: "warn"}); err == nil {
+		t.Error("RegisterLevel should reject a taken name")
+	}
+	if err := RegisterLevel(LevelInfo+3, LogTag{Name: " "}); err == nil {
+		t.Error("RegisterLevel should reject an empty name")
+	}
+	if levels := Levels(); levels[0] != levelTrace || levels[3] != levelNotice {
+		t.Errorf("Levels should be sorted, got %v", levels)
+	}
+	if stepLevel(LevelInfo, 1) != levelNotice || stepLevel(LevelDebug, -1) != levelTrace || stepLevel(levelTrace, -1) != levelTrace {
+		t.Error("stepLevel should move between registered levels")
+	}
+}
+
+// TestCustomLevelLogging checks filtering, encoders and the HTTP endpoint handle custom levels.
+func TestCustomLevelLogging(t *testing.T) {
+	const levelAudit = LevelError + 1
+	registerTestLevel(t, levelAudit, "AUDIT")
+
+	var buf bytes.Buffer
+	l := New(WithOutput(&buf), WithEncoder(LogfmtEncoder{}), WithCaller(false), WithLevel(LevelError))
+	l.Log(LevelWarn, "hidden")
+	l.Logw(levelAudit, "login", "user", "ada")
+	if got := buf.String(); !strings.HasPrefix(got, "level=audit ") || strings.Contains(got, "hidden") {
+		t.Errorf("logfmt output: %q", got)
+	}
+
+	buf.Reset()
+	l = New(WithOutput(&buf), WithEncoder(TextEncoder{NoColor: true}), WithLevel(levelAudit))
+	l.Log(LevelError, "hidden")
+	l.Log(levelAudit, "login")
+	if got := buf.String(); !strings.Contains(got, "[AUDIT]") || strings.Contains(got, "hidden") {
+		t.Errorf("text output: %q", got)
+	}
+
+	a := NewAtomicLevel(LevelInfo)
+	rec := httptest.NewRecorder()
+	a.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/level", strings.NewReader(`{"level":"audit"}`)))
+	if rec.Code != http.StatusOK || a.Level() != levelAudit || strings.TrimSpace(rec.Body.String()) != `{"level":"audit"}` {
+		t.Errorf("PUT: %d %q, level %v", rec.Code, rec.Body.String(), a.Level())
+	}
+}
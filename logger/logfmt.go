@@ -0,0 +1,77 @@
+package logger
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+)
+
+// LogfmtEncoder renders entries as logfmt lines: level=info ts=... caller=main.go:42 msg="..." k=v.
+// ts is left out when the entry has no time.
+type LogfmtEncoder struct {
+	// TimeFormat defaults to TimeFormatRFC3339Nano.
+	TimeFormat TimeFormat
+}
+
+// Encode implements Encoder.
+func (enc LogfmtEncoder) Encode(buf *bytes.Buffer, e *Entry) error {
+	buf.WriteString("level=")
+	if needsQuoting(e.Tag.Name) {
+		writeValue(buf, strings.ToLower(e.Tag.Name))
+	} else {
+		writeLower(buf, e.Tag.Name)
+	}
+	if !e.Time.IsZero() {
+		buf.WriteString(" ts=")
+		if ts := enc.TimeFormat.or(TimeFormatRFC3339Nano).appendTo(buf.AvailableBuffer(), e.Time); needsQuoting(ts) {
+			writeValue(buf, string(ts))
+		} else {
+			buf.Write(ts)
+		}
+	}
+	if e.Caller.Defined() {
+		buf.WriteString(" caller=")
+		if needsQuoting(filepath.Base(e.Caller.File)) {
+			writeValue(buf, e.Caller.String())
+		} else {
+			writeCaller(buf, e.Caller)
+		}
+		if e.Caller.Function != "" {
+			buf.WriteString(" func=")
+			writeValue(buf, e.Caller.Function)
+		}
+	}
+	if e.Logger != "" {
+		buf.WriteString(" logger=")
+		writeValue(buf, e.Logger)
+	}
+	buf.WriteString(" msg=")
+	writeValue(buf, e.Message)
+	for _, f := range e.Fields {
+		buf.WriteByte(' ')
+		writeLogfmtKey(buf, f.Key)
+		buf.WriteByte('=')
+		writeFieldValue(buf, f)
+	}
+	if e.Stack != "" {
+		buf.WriteString(" stack=")
+		writeValue(buf, e.Stack)
+	}
+	buf.WriteByte('\n')
+	return nil
+}
+
+// writeLogfmtKey writes key, replacing characters that would break the key=value syntax with '_'.
+func writeLogfmtKey(buf *bytes.Buffer, key string) {
+	if key == "" {
+		buf.WriteByte('_')
+		return
+	}
+	for _, r := range key {
+		if r <= ' ' || r == '=' || r == '"' || r == 0x7f {
+			buf.WriteByte('_')
+			continue
+		}
+		buf.WriteRune(r)
+	}
+}
@@ -0,0 +1,35 @@
+package logger
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestLogfmtEncoder checks the logfmt line layout for a fixed entry.
+func TestLogfmtEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	LogfmtEncoder{}.Encode(&buf, testEntry())
+	want := `level=warn ts=2024-05-01T12:30:45Z caller=main.go:42 msg="disk almost full" path=/var/log used=0.93 err=quota` + "\n"
+	if buf.String() != want {
+		t.Errorf("LogfmtEncoder: got %q, want %q", buf.String(), want)
+	}
+}
+
+// TestLogfmtEscaping checks quoting of spaces, quotes, newlines, empty values and bad keys.
+func TestLogfmtEscaping(t *testing.T) {
+	e := testEntry()
+	e.Message = "ok"
+	e.Fields = []Field{
+		F("q", `say "hi"`),
+		F("nl", "line1\nline2"),
+		F("empty", ""),
+		F("bad key=", "v"),
+		F("eq", "a=b"),
+	}
+	var buf bytes.Buffer
+	LogfmtEncoder{}.Encode(&buf, e)
+	want := `level=warn ts=2024-05-01T12:30:45Z caller=main.go:42 msg=ok q="say \"hi\"" nl="line1\nline2" empty="" bad_key_=v eq="a=b"` + "\n"
+	if buf.String() != want {
+		t.Errorf("LogfmtEncoder escaping:\n got %q\nwant %q", buf.String(), want)
+	}
+}
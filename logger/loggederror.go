@@ -0,0 +1,129 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// LoggedError is the error returned by Error, Errorw and ErrorCtx. It records what
+// was logged so the error can be inspected or passed to LogError upstream without
+// being printed twice.
+type LoggedError struct {
+	Level   Level
+	Message string
+	Fields  []Field
+	Caller  Caller
+	Time    time.Time
+
+	cause  error
+	causes []error // error-valued fields, saved before redaction
+	logged atomic.Bool
+}
+
+// Error implements error.
+func (e *LoggedError) Error() string {
+	return e.cause.Error()
+}
+
+// Unwrap returns the errors wrapped with %w and any error-valued fields,
+// so errors.Is and errors.As see through a LoggedError.
+func (e *LoggedError) Unwrap() []error {
+	var causes []error
+	switch u := e.cause.(type) {
+	case interface{ Unwrap() error }:
+		if c := u.Unwrap(); c != nil {
+			causes = append(causes, c)
+		}
+	case interface{ Unwrap() []error }:
+		causes = append(causes, u.Unwrap()...)
+	}
+	return append(causes, e.causes...)
+}
+
+// errorFields appends the error values of fields to causes.
+func errorFields(causes []error, fields []Field) []error {
+	for _, f := range fields {
+		if err, ok := f.Value.(error); ok && f.Kind == AnyKind {
+			causes = append(causes, err)
+		}
+	}
+	return causes
+}
+
+// Logged reports whether the error has already been written by a logger.
+func (e *LoggedError) Logged() bool {
+	return e.logged.Load()
+}
+
+// logError builds a LoggedError for cause and writes it at ERROR level when enabled.
+// It must be called directly from the exported logging function so the caller lookup is correct.
+func (l *Logger) logError(cause error, fields []Field) error {
+	e := l.newEntry(LevelError, cause.Error(), fields, 2)
+	// Redaction may turn error fields into strings, so keep the errors for Unwrap first.
+	causes := errorFields(errorFields(nil, l.fields), fields)
+	l.prepare(e)
+	le := &LoggedError{
+		Level:   e.Level,
+		Message: e.Message,
+		Fields:  e.Fields,
+		Caller:  e.Caller,
+		Time:    e.Time,
+		cause:   cause,
+		causes:  causes,
+	}
+	if l.Enabled(LevelError) {
+		le.logged.Store(true)
+		l.sink.Write(e)
+	}
+	return le
+}
+
+// logRecorded writes le as it was recorded when created, unless it has been written already.
+func (l *Logger) logRecorded(le *LoggedError) {
+	if !l.Enabled(le.Level) || !le.logged.CompareAndSwap(false, true) {
+		return
+	}
+	e := getEntry()
+	e.Time = le.Time
+	if l.location != nil {
+		e.Time = e.Time.In(l.location)
+	}
+	e.Level = le.Level
+	e.Tag = l.tag(le.Level)
+	e.Caller = le.Caller
+	e.Logger = l.name
+	e.Message = le.Message
+	e.Fields = append(e.Fields, le.Fields...)
+	l.sink.Write(e)
+	putEntry(e)
+}
+
+// LogError logs err at ERROR level and returns it. If err is, or wraps, a LoggedError,
+// its recorded message, fields, caller and time are written unless that already
+// happened, so the error is never printed twice.
+func (l *Logger) LogError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var le *LoggedError
+	if errors.As(err, &le) {
+		l.logRecorded(le)
+		return err
+	}
+	return l.logError(fmt.Errorf("%w", err), nil)
+}
+
+// LogError logs err on the default logger unless it was already logged.
+func LogError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var le *LoggedError
+	if errors.As(err, &le) {
+		Default().logRecorded(le)
+		return err
+	}
+	return Default().logError(fmt.Errorf("%w", err), nil)
+}
@@ -0,0 +1,116 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+	"testing"
+)
+
+// TestLoggedErrorUnwrap checks errors.Is and errors.As through %w causes and error fields.
+func TestLoggedErrorUnwrap(t *testing.T) {
+	l := New(WithSink(&memorySink{}))
+
+	err := l.Error("read config: %w", io.EOF)
+	if !errors.Is(err, io.EOF) {
+		t.Errorf("errors.Is should find the %%w cause: %v", err)
+	}
+	var le *LoggedError
+	if !errors.As(err, &le) || !le.Logged() || le.Level != LevelError {
+		t.Fatalf("errors.As should find a logged LoggedError: %#v", err)
+	}
+	if !strings.HasPrefix(le.Caller.String(), "loggederror_test.go:") {
+		t.Errorf("call site not recorded: %q", le.Caller.String())
+	}
+
+	cause := errors.New("conn refused")
+	err = l.Errorw("query failed", "error", cause, "table", "users")
+	if !errors.Is(err, cause) || err.Error() != "query failed" {
+		t.Errorf("error fields should be causes: %v", err)
+	}
+	if errors.As(err, &le); len(le.Fields) != 2 || le.Fields[1].Value != "users" {
+		t.Errorf("fields not recorded: %v", le.Fields)
+	}
+}
+
+// TestLogErrorNoDoublePrint checks that already-logged errors are not printed again upstream.
+func TestLogErrorNoDoublePrint(t *testing.T) {
+	mem := &memorySink{}
+	l := New(WithSink(mem))
+
+	err := l.Error("disk full")
+	wrapped := fmt.Errorf("save failed: %w", err)
+	if got := l.LogError(wrapped); got != wrapped {
+		t.Errorf("LogError should return the error unchanged, got %v", got)
+	}
+	if got := mem.snapshot(); len(got) != 1 {
+		t.Errorf("already logged error was printed again: %v", got)
+	}
+
+	plain := errors.New("timeout")
+	if got := l.LogError(plain); !errors.Is(got, plain) {
+		t.Errorf("LogError result should wrap the original: %v", got)
+	}
+	if got := mem.snapshot(); len(got) != 2 || got[1] != "timeout" {
+		t.Errorf("unlogged error should be printed once: %v", got)
+	}
+}
+
+// TestLoggedErrorDisabled checks that errors created below the level are not marked as logged.
+func TestLoggedErrorDisabled(t *testing.T) {
+	mem := &memorySink{}
+	l := New(WithSink(mem), WithLevel(LevelFatal))
+	err := l.Error("quiet")
+	var le *LoggedError
+	if !errors.As(err, &le) || le.Logged() {
+		t.Errorf("error below the level should not be marked logged: %#v", err)
+	}
+	l.SetLevel(LevelInfo)
+	l.LogError(err)
+	if got := mem.snapshot(); len(got) != 1 {
+		t.Errorf("LogError should print an error that was never written: %v", got)
+	}
+}
+
+// TestLogErrorRecorded checks LogError writes an unlogged LoggedError as recorded, and only once.
+func TestLogErrorRecorded(t *testing.T) {
+	quiet := New(WithSink(&memorySink{}), WithLevel(LevelFatal))
+	line := nextLine()
+	err := quiet.Errorw("query failed", "table", "users")
+	wrapped := fmt.Errorf("load: %w", err)
+
+	sink := &entrySink{}
+	if got := New(WithSink(sink)).LogError(wrapped); got != wrapped {
+		t.Errorf("LogError should return the error unchanged, got %v", got)
+	}
+	mem := &memorySink{}
+	New(WithSink(mem)).LogError(wrapped)
+	if got := mem.snapshot(); len(got) != 0 {
+		t.Errorf("recorded error should be printed once: %v", got)
+	}
+	var le *LoggedError
+	errors.As(err, &le)
+	e := sink.last
+	if e.Message != "query failed" || len(e.Fields) != 1 || e.Fields[0].Key != "table" || e.Caller.Line != line || !e.Time.Equal(le.Time) {
+		t.Errorf("entry not built from the recorded error: %+v", e)
+	}
+}
+
+// TestLoggedErrorUnwrapRedacted checks error fields stay causes after the redactor masks them.
+func TestLoggedErrorUnwrapRedacted(t *testing.T) {
+	l := New(WithSink(&memorySink{}), WithRedactor(DefaultRedactor()))
+	cause := &fs.PathError{Op: "open", Path: "/tmp/Bearer abcdef", Err: fs.ErrNotExist}
+	err := l.Errorw("failed", "err", cause, "token", errors.New("secret"))
+
+	var le *LoggedError
+	errors.As(err, &le)
+	if s, ok := le.Fields[0].Value.(string); !ok || strings.Contains(s, "abcdef") {
+		t.Fatalf("error field should be redacted: %#v", le.Fields[0].Value)
+	}
+	var pe *fs.PathError
+	if !errors.Is(err, fs.ErrNotExist) || !errors.As(err, &pe) {
+		t.Errorf("redacted error fields should still be causes: %v", le.Unwrap())
+	}
+}
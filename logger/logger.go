@@ -4,10 +4,11 @@ import (
 	"fmt"
 	"github.com/isaacwallace123/GoUtils/color"
 	"github.com/isaacwallace123/GoUtils/timeutil"
+	"io"
 	"os"
-	"path/filepath"
-	"runtime"
-	"sync"
+	"strings"
+	"sync/atomic"
+	"time"
 )
 
 // LogTag holds a log level name and its associated color.
@@ -16,82 +17,364 @@ type LogTag struct {
 	Color string
 }
 
+// Level is the severity of a log entry. The built-in levels are spaced like
+// log/slog so custom levels registered with RegisterLevel can sit between them.
 type Level int
 
 const (
-	LevelDebug Level = iota
-	LevelInfo
-	LevelWarn
-	LevelError
+	LevelDebug Level = -4
+	LevelInfo  Level = 0
+	LevelWarn  Level = 4
+	LevelError Level = 8
+	LevelPanic Level = 10
+	LevelFatal Level = 12
 )
 
-var currentLevel = LevelInfo
-
-// SetLevel sets the current log level.
-func SetLevel(level Level) {
-	currentLevel = level
-}
-
 // Use color semantic colors for each log tag.
 var (
-	info  = LogTag{"INFO", color.InfoColor}
-	warn  = LogTag{"WARN", color.WarnColor}
-	err   = LogTag{"ERROR", color.ErrorColor}
-	fatal = LogTag{"FATAL", color.ErrorColor}
-	debug = LogTag{"DEBUG", color.DebugColor}
+	DebugTag = LogTag{"DEBUG", color.DebugColor}
+	InfoTag  = LogTag{"INFO", color.InfoColor}
+	WarnTag  = LogTag{"WARN", color.WarnColor}
+	ErrorTag = LogTag{"ERROR", color.ErrorColor}
+	PanicTag = LogTag{"PANIC", color.ErrorColor}
+	FatalTag = LogTag{"FATAL", color.ErrorColor}
 )
 
 const reset = color.Reset
 
-var logLock sync.Mutex
+// Logger is a leveled logger with its own level, output, prefix and tag set.
+// A Logger is safe for concurrent use. Child loggers share their parent's level.
+type Logger struct {
+	name      string
+	level     *AtomicLevel
+	overrides *LevelOverrides
+	out       io.Writer
+	encoder   Encoder
+	sink      Sink
+	prefix    string
+	tags      map[Level]LogTag
+	fields    []Field
+
+	addCaller  bool
+	callerFunc bool
+	callerSkip int
+	addStack   bool
+	stackLevel Level
+	exitFunc   func(code int)
+	redactor   Redactor
+
+	now        func() time.Time
+	location   *time.Location
+	timeFormat TimeFormat
+}
+
+// Option configures a Logger created with New.
+type Option func(*Logger)
+
+// WithLevel sets the minimum level the logger will print.
+func WithLevel(level Level) Option {
+	return func(l *Logger) {
+		l.level.SetLevel(level)
+	}
+}
+
+// WithAtomicLevel makes the logger use the shared AtomicLevel a, so its level can be changed at runtime.
+func WithAtomicLevel(a *AtomicLevel) Option {
+	return func(l *Logger) {
+		l.level = a
+	}
+}
+
+// WithOutput sets the writer log lines are written to.
+func WithOutput(w io.Writer) Option {
+	return func(l *Logger) {
+		l.out = w
+	}
+}
 
-// log prints a formatted log message with the correct color, timestamp, file, etc.
-func log(t LogTag, message string, args ...interface{}) {
-	logLock.Lock()
-	defer logLock.Unlock()
+// WithEncoder sets the encoder used to format entries.
+func WithEncoder(enc Encoder) Option {
+	return func(l *Logger) {
+		l.encoder = enc
+	}
+}
 
-	_, file, _, ok := runtime.Caller(2)
-	if !ok {
-		file = "???"
+// WithSink sends entries to sink instead of encoding them to the output.
+func WithSink(sink Sink) Option {
+	return func(l *Logger) {
+		l.sink = sink
 	}
-	shortFile := filepath.Base(file)
-	timestamp := timeutil.FormatDateTime(timeutil.NowLocal())
-	formatted := fmt.Sprintf(message, args...)
+}
 
-	fmt.Printf("[%s] %s[%s]%s [%s] %s\n", timestamp, t.Color, t.Name, reset, shortFile, formatted)
+// WithPrefix sets a prefix printed before every message.
+func WithPrefix(prefix string) Option {
+	return func(l *Logger) {
+		l.prefix = prefix
+	}
+}
+
+// WithTags overrides the tags used for the given levels by this logger.
+// Levels missing from tags keep their registered tag.
+func WithTags(tags map[Level]LogTag) Option {
+	return func(l *Logger) {
+		for level, tag := range tags {
+			l.tags[level] = tag
+		}
+	}
+}
+
+// New creates a Logger writing to stdout at INFO level, then applies opts.
+// Without WithEncoder it uses a TextEncoder. A TextEncoder left at ColorAuto only
+// emits colors when the output supports them (see color.ProfileFor).
+func New(opts ...Option) *Logger {
+	l := &Logger{
+		level:     NewAtomicLevel(LevelInfo),
+		overrides: NewLevelOverrides(),
+		out:       os.Stdout,
+		tags:      make(map[Level]LogTag),
+		addCaller: true,
+		now:       timeutil.NowLocal,
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	if l.encoder == nil {
+		l.encoder = TextEncoder{TimeFormat: l.timeFormat}
+	} else if l.timeFormat != "" {
+		l.encoder = applyTimeFormat(l.encoder, l.timeFormat)
+	}
+	l.encoder = resolveColor(l.encoder, l.out)
+	if l.sink == nil {
+		l.sink = NewWriterSink(l.out, l.encoder)
+	}
+	return l
+}
+
+// SetLevel sets the minimum level the logger will print.
+func (l *Logger) SetLevel(level Level) {
+	l.level.SetLevel(level)
+}
+
+// GetLevel returns the minimum level the logger will print,
+// taking any override for the logger's name into account.
+func (l *Logger) GetLevel() Level {
+	if l.name != "" {
+		if level, ok := l.overrides.Lookup(l.name); ok {
+			return level
+		}
+	}
+	return l.level.Level()
+}
+
+// AtomicLevel returns the level shared by the logger and its children.
+func (l *Logger) AtomicLevel() *AtomicLevel {
+	return l.level
+}
+
+// Enabled reports whether messages at level would be printed.
+func (l *Logger) Enabled(level Level) bool {
+	return l.GetLevel() <= level
+}
+
+// tag returns the logger's tag for level, falling back to the registered tag.
+func (l *Logger) tag(level Level) LogTag {
+	if t, ok := l.tags[level]; ok {
+		return t
+	}
+	return levelTag(level)
+}
+
+// log builds an entry with the timestamp, file, message and fields, writes it and recycles it.
+// It must be called directly from the exported logging function so the caller lookup is correct.
+func (l *Logger) log(level Level, message string, fields []Field) {
+	e := l.newEntry(level, message, fields, 2)
+	l.write(e)
+	putEntry(e)
+}
+
+// newEntry builds a pooled entry whose caller and stack start depth frames above newEntry's caller.
+// fields are copied into the entry, so the caller's slice does not escape.
+func (l *Logger) newEntry(level Level, message string, fields []Field, depth int) *Entry {
+	var caller Caller
+	if l.addCaller {
+		caller = callerAt(depth+1+l.callerSkip, l.callerFunc)
+	}
+	var stack string
+	if l.addStack && level >= l.stackLevel {
+		stack = captureStack(depth + 1 + l.callerSkip)
+	}
+	e := getEntry()
+	e.Time = l.now()
+	e.Level = level
+	e.Tag = l.tag(level)
+	e.Caller = caller
+	e.Message = message
+	e.Fields = append(e.Fields, fields...)
+	e.Stack = stack
+	return e
+}
+
+// prepare adds the logger's name, prefix and persistent fields to e, converts its time
+// to the logger's location and redacts it.
+func (l *Logger) prepare(e *Entry) {
+	if l.location != nil {
+		e.Time = e.Time.In(l.location)
+	}
+	e.Logger = l.name
+	e.Message = l.prefix + e.Message
+	if n := len(l.fields); n > 0 {
+		e.Fields = append(e.Fields, l.fields...)
+		copy(e.Fields[n:], e.Fields[:len(e.Fields)-n])
+		copy(e.Fields, l.fields)
+	}
+	if l.redactor != nil {
+		l.redactor.Redact(e)
+	}
+}
+
+// sprintf formats message with args, skipping fmt when there is nothing to format.
+func sprintf(message string, args ...interface{}) string {
+	if len(args) == 0 && strings.IndexByte(message, '%') < 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// write prepares e and hands it to the sink.
+func (l *Logger) write(e *Entry) error {
+	l.prepare(e)
+	return l.sink.Write(e)
+}
+
+// Flush writes out any entries buffered by the logger's sink.
+func (l *Logger) Flush() error {
+	if f, ok := l.sink.(Flusher); ok {
+		return f.Flush()
+	}
+	return nil
 }
 
 // Debug logs a message at DEBUG level.
-func Debug(message string, args ...interface{}) {
-	if currentLevel <= LevelDebug {
-		log(debug, message, args...)
+func (l *Logger) Debug(message string, args ...interface{}) {
+	if l.Enabled(LevelDebug) {
+		l.log(LevelDebug, sprintf(message, args...), nil)
 	}
 }
 
 // Info logs a message at INFO level.
-func Info(message string, args ...interface{}) {
-	if currentLevel <= LevelInfo {
-		log(info, message, args...)
+func (l *Logger) Info(message string, args ...interface{}) {
+	if l.Enabled(LevelInfo) {
+		l.log(LevelInfo, sprintf(message, args...), nil)
 	}
 }
 
 // Warn logs a message at WARN level.
+func (l *Logger) Warn(message string, args ...interface{}) {
+	if l.Enabled(LevelWarn) {
+		l.log(LevelWarn, sprintf(message, args...), nil)
+	}
+}
+
+// Error logs a message at ERROR level and returns it as a *LoggedError.
+// Causes passed with %w can be matched with errors.Is and errors.As.
+func (l *Logger) Error(message string, args ...interface{}) error {
+	return l.logError(fmt.Errorf(message, args...), nil)
+}
+
+// Panic logs a message at PANIC level and then panics with it.
+func (l *Logger) Panic(message string, args ...interface{}) {
+	formatted := sprintf(message, args...)
+	l.log(LevelPanic, formatted, nil)
+	l.Flush()
+	panic(formatted)
+}
+
+// Fatal logs a message, runs the exit hooks and exits the application.
+func (l *Logger) Fatal(message string, args ...interface{}) {
+	l.log(LevelFatal, sprintf(message, args...), nil)
+	l.exit(1)
+}
+
+// Log logs a message at level, which may be a custom level registered with RegisterLevel.
+// Unlike Panic and Fatal, it never panics or exits.
+func (l *Logger) Log(level Level, message string, args ...interface{}) {
+	if l.Enabled(level) {
+		l.log(level, sprintf(message, args...), nil)
+	}
+}
+
+// std is the logger used by the package-level functions.
+var std atomic.Pointer[Logger]
+
+func init() {
+	std.Store(New(WithLevelsFromEnv(LevelEnvVar)))
+}
+
+// Default returns the logger used by the package-level functions.
+func Default() *Logger {
+	return std.Load()
+}
+
+// SetDefault replaces the logger used by the package-level functions.
+func SetDefault(l *Logger) {
+	std.Store(l)
+}
+
+// SetLevel sets the level of the default logger.
+func SetLevel(level Level) {
+	Default().SetLevel(level)
+}
+
+// Flush writes out any entries buffered by the default logger's sink.
+func Flush() error {
+	return Default().Flush()
+}
+
+// Debug logs a message at DEBUG level on the default logger.
+func Debug(message string, args ...interface{}) {
+	if l := Default(); l.Enabled(LevelDebug) {
+		l.log(LevelDebug, sprintf(message, args...), nil)
+	}
+}
+
+// Info logs a message at INFO level on the default logger.
+func Info(message string, args ...interface{}) {
+	if l := Default(); l.Enabled(LevelInfo) {
+		l.log(LevelInfo, sprintf(message, args...), nil)
+	}
+}
+
+// Warn logs a message at WARN level on the default logger.
 func Warn(message string, args ...interface{}) {
-	if currentLevel <= LevelWarn {
-		log(warn, message, args...)
+	if l := Default(); l.Enabled(LevelWarn) {
+		l.log(LevelWarn, sprintf(message, args...), nil)
 	}
 }
 
-// Error logs a message at ERROR level and returns an error object.
+// Error logs a message at ERROR level on the default logger and returns it as a *LoggedError.
 func Error(message string, args ...interface{}) error {
-	if currentLevel <= LevelError {
-		log(err, message, args...)
-	}
-	return fmt.Errorf(message, args...)
+	return Default().logError(fmt.Errorf(message, args...), nil)
+}
+
+// Panic logs a message at PANIC level on the default logger and then panics with it.
+func Panic(message string, args ...interface{}) {
+	l := Default()
+	formatted := sprintf(message, args...)
+	l.log(LevelPanic, formatted, nil)
+	l.Flush()
+	panic(formatted)
 }
 
-// Fatal logs a message and exits the application.
+// Fatal logs a message on the default logger, runs the exit hooks and exits the application.
 func Fatal(message string, args ...interface{}) {
-	log(fatal, message, args...)
-	os.Exit(1)
+	l := Default()
+	l.log(LevelFatal, sprintf(message, args...), nil)
+	l.exit(1)
+}
+
+// Log logs a message at level on the default logger.
+func Log(level Level, message string, args ...interface{}) {
+	if l := Default(); l.Enabled(level) {
+		l.log(level, sprintf(message, args...), nil)
+	}
 }
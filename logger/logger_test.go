@@ -1,7 +1,12 @@
 package logger
 
 import (
+	"bytes"
+	"github.com/isaacwallace123/GoUtils/color"
+	"io"
+	"strings"
 	"testing"
+	"time"
 )
 
 // TestLoggerLevels checks that log messages print at each level and Error returns an error.
@@ -14,7 +19,7 @@ func TestLoggerLevels(t *testing.T) {
 	if err == nil {
 		t.Error("Error() should return error")
 	}
-	// Don't call Fatal: it exits the program
+	// Fatal is covered in exit_test.go with a stub exit function
 	SetLevel(LevelError)
 	Info("This should not be visible") // Should NOT print due to log level
 }
@@ -34,3 +39,123 @@ func TestLoggerThreadSafe(t *testing.T) {
 	<-done
 	<-done
 }
+
+// TestLoggerInstances checks that separate loggers keep their own level, output and prefix.
+func TestLoggerInstances(t *testing.T) {
+	var dbOut, httpOut bytes.Buffer
+	db := New(WithLevel(LevelDebug), WithOutput(&dbOut), WithPrefix("db: "))
+	http := New(WithLevel(LevelWarn), WithOutput(&httpOut))
+
+	db.Debug("query %d", 1)
+	http.Info("request") // Should NOT print due to log level
+	http.Warn("slow request")
+
+	if !strings.Contains(dbOut.String(), "[DEBUG]") || !strings.Contains(dbOut.String(), "db: query 1") {
+		t.Errorf("db logger output missing debug line: %q", dbOut.String())
+	}
+	if strings.Count(httpOut.String(), "\n") != 1 {
+		t.Errorf("http logger should print exactly one line, got %q", httpOut.String())
+	}
+}
+
+// TestLoggerTags checks that WithTags overrides the tag of a single level.
+func TestLoggerTags(t *testing.T) {
+	var out bytes.Buffer
+	l := New(WithOutput(&out), WithEncoder(TextEncoder{Color: ColorAlways}), WithTags(map[Level]LogTag{LevelInfo: {"NOTE", color.Cyan}}))
+	l.Info("hello")
+	l.Warn("careful")
+	if !strings.Contains(out.String(), color.Cyan+"[NOTE]") {
+		t.Errorf("custom tag not used: %q", out.String())
+	}
+	if !strings.Contains(out.String(), "[WARN]") {
+		t.Errorf("default tag missing for WARN: %q", out.String())
+	}
+}
+
+// TestLoggerAutoColor checks that text encoders left at ColorAuto only color outputs that support it.
+func TestLoggerAutoColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_COLOR", "1")
+	var forced bytes.Buffer
+	New(WithOutput(&forced)).Info("forced")
+	if !strings.Contains(forced.String(), color.InfoColor) {
+		t.Errorf("FORCE_COLOR should enable colors: %q", forced.String())
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	var plain, explicit, fanned, always bytes.Buffer
+	New(WithOutput(&plain)).Info("plain")
+	New(WithOutput(&explicit), WithEncoder(TextEncoder{})).Info("explicit")
+	New(WithSink(NewFanOutSink(To(LevelInfo, &fanned, TextEncoder{})))).Info("fanned")
+	for _, out := range []*bytes.Buffer{&plain, &explicit, &fanned} {
+		if strings.Contains(out.String(), "\033[") {
+			t.Errorf("NO_COLOR should disable colors: %q", out.String())
+		}
+	}
+	New(WithOutput(&always), WithEncoder(TextEncoder{Color: ColorAlways})).Info("always")
+	if !strings.Contains(always.String(), color.InfoColor) {
+		t.Errorf("ColorAlways should keep colors: %q", always.String())
+	}
+}
+
+// benchmarkLogger returns a JSON logger writing to io.Discard with a persistent field.
+func benchmarkLogger() *Logger {
+	return New(WithOutput(io.Discard), WithEncoder(JSONEncoder{})).With("service", "api")
+}
+
+// BenchmarkDisabled measures calls below the logger's level.
+func BenchmarkDisabled(b *testing.B) {
+	l := benchmarkLogger()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Debug("request handled")
+		l.LogFields(LevelDebug, "request handled", String("path", "/users"), Int("status", i))
+	}
+}
+
+// BenchmarkInfo measures a plain message with caller lookup.
+func BenchmarkInfo(b *testing.B) {
+	l := benchmarkLogger()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Info("request handled")
+	}
+}
+
+// BenchmarkInfof measures a formatted message.
+func BenchmarkInfof(b *testing.B) {
+	l := benchmarkLogger()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Info("request %d handled", i)
+	}
+}
+
+// BenchmarkInfow measures loosely typed key/value pairs, which are boxed.
+func BenchmarkInfow(b *testing.B) {
+	l := benchmarkLogger()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Infow("request handled", "path", "/users", "status", i, "latency", 3*time.Millisecond)
+	}
+}
+
+// BenchmarkLogFields measures typed fields, which avoid boxing.
+func BenchmarkLogFields(b *testing.B) {
+	l := benchmarkLogger()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.LogFields(LevelInfo, "request handled", String("path", "/users"), Int("status", i), Duration("latency", 3*time.Millisecond))
+	}
+}
+
+// BenchmarkLogFieldsParallel measures typed fields from many goroutines.
+func BenchmarkLogFieldsParallel(b *testing.B) {
+	l := benchmarkLogger()
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			l.LogFields(LevelInfo, "request handled", String("path", "/users"), Int("status", i), Duration("latency", 3*time.Millisecond))
+		}
+	})
+}
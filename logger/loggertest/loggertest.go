@@ -0,0 +1,155 @@
+// Package loggertest captures logger output in memory so tests can assert on it.
+package loggertest
+
+import (
+	"bytes"
+	"github.com/isaacwallace123/GoUtils/logger"
+	"math"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Recorder is a logger.Sink that keeps every entry it receives.
+type Recorder struct {
+	mu      sync.Mutex
+	entries []logger.Entry
+}
+
+// NewRecorder returns an empty Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// New returns a DEBUG level logger that records into a fresh Recorder.
+// Extra options are applied after the recorder sink.
+func New(opts ...logger.Option) (*logger.Logger, *Recorder) {
+	rec := NewRecorder()
+	opts = append([]logger.Option{logger.WithLevel(logger.LevelDebug), logger.WithSink(rec)}, opts...)
+	return logger.New(opts...), rec
+}
+
+// CaptureDefault replaces the default logger with a recording one until the test ends.
+func CaptureDefault(t testing.TB) *Recorder {
+	t.Helper()
+	previous := logger.Default()
+	l, rec := New()
+	logger.SetDefault(l)
+	t.Cleanup(func() { logger.SetDefault(previous) })
+	return rec
+}
+
+// Write implements logger.Sink.
+func (r *Recorder) Write(e *logger.Entry) error {
+	entry := *e
+	entry.Fields = append([]logger.Field(nil), e.Fields...)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+// Entries returns a copy of the recorded entries.
+func (r *Recorder) Entries() []logger.Entry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]logger.Entry(nil), r.entries...)
+}
+
+// Reset discards all recorded entries.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = nil
+}
+
+// Find returns the recorded entries at level whose message contains substr.
+func (r *Recorder) Find(level logger.Level, substr string) []logger.Entry {
+	var found []logger.Entry
+	for _, e := range r.Entries() {
+		if e.Level == level && strings.Contains(e.Message, substr) {
+			found = append(found, e)
+		}
+	}
+	return found
+}
+
+// Field returns the value of the first field named key in e.
+func Field(e logger.Entry, key string) (interface{}, bool) {
+	for _, f := range e.Fields {
+		if f.Key == key {
+			return f.Any(), true
+		}
+	}
+	return nil, false
+}
+
+// AssertLogged fails the test unless an entry at level with a message containing substr was recorded.
+func (r *Recorder) AssertLogged(t testing.TB, level logger.Level, substr string) {
+	t.Helper()
+	if len(r.Find(level, substr)) == 0 {
+		t.Errorf("no entry at level %v containing %q; recorded:\n%s", level, substr, r.dump())
+	}
+}
+
+// AssertNotLogged fails the test if an entry at level with a message containing substr was recorded.
+func (r *Recorder) AssertNotLogged(t testing.TB, level logger.Level, substr string) {
+	t.Helper()
+	if found := r.Find(level, substr); len(found) > 0 {
+		t.Errorf("unexpected entry at level %v containing %q; recorded:\n%s", level, substr, r.dump())
+	}
+}
+
+// AssertField fails the test unless an entry whose message contains substr has field key equal to value.
+// Values are compared with reflect.DeepEqual, except that integers of any type match by value,
+// so 5 matches a field logged with logger.Int.
+func (r *Recorder) AssertField(t testing.TB, substr, key string, value interface{}) {
+	t.Helper()
+	for _, e := range r.Entries() {
+		if !strings.Contains(e.Message, substr) {
+			continue
+		}
+		if v, ok := Field(e, key); ok && valuesEqual(v, value) {
+			return
+		}
+	}
+	t.Errorf("no entry containing %q with %s=%v; recorded:\n%s", substr, key, value, r.dump())
+}
+
+// valuesEqual reports whether got and want are deeply equal or are integers with the same value.
+func valuesEqual(got, want interface{}) bool {
+	if reflect.DeepEqual(got, want) {
+		return true
+	}
+	g, gok := integer(got)
+	w, wok := integer(want)
+	return gok && wok && g == w
+}
+
+// integer returns v as an int64 if it is an integer of any type that fits in one.
+func integer(v interface{}) (int64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if u := rv.Uint(); u <= math.MaxInt64 {
+			return int64(u), true
+		}
+	}
+	return 0, false
+}
+
+// dump renders the recorded entries in logfmt for failure messages.
+func (r *Recorder) dump() string {
+	var buf bytes.Buffer
+	for _, e := range r.Entries() {
+		buf.WriteString("  ")
+		logger.LogfmtEncoder{}.Encode(&buf, &e)
+	}
+	if buf.Len() == 0 {
+		return "  (none)\n"
+	}
+	return buf.String()
+}
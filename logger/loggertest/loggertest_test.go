@@ -0,0 +1,89 @@
+package loggertest
+
+import (
+	"fmt"
+	"github.com/isaacwallace123/GoUtils/logger"
+	"strings"
+	"testing"
+)
+
+// TestRecorder checks that entries are captured with level, message, fields and caller.
+func TestRecorder(t *testing.T) {
+	l, rec := New()
+	l.With("component", "cache").Warnw("evicted", "keys", 12)
+	l.Debug("miss %d", 1)
+
+	rec.AssertLogged(t, logger.LevelWarn, "evicted")
+	rec.AssertLogged(t, logger.LevelDebug, "miss 1")
+	rec.AssertNotLogged(t, logger.LevelError, "evicted")
+	rec.AssertField(t, "evicted", "keys", 12)
+	rec.AssertField(t, "evicted", "component", "cache")
+
+	entries := rec.Entries()
+	if len(entries) != 2 || !strings.HasPrefix(entries[0].Caller.String(), "loggertest_test.go:") {
+		t.Errorf("unexpected entries: %+v", entries)
+	}
+	rec.Reset()
+	if len(rec.Entries()) != 0 {
+		t.Error("Reset should discard entries")
+	}
+}
+
+// TestCaptureDefault checks that package-level calls are recorded and the default is restored.
+func TestCaptureDefault(t *testing.T) {
+	previous := logger.Default()
+	t.Run("capture", func(t *testing.T) {
+		rec := CaptureDefault(t)
+		logger.Info("from package %s", "level")
+		rec.AssertLogged(t, logger.LevelInfo, "from package level")
+	})
+	if logger.Default() != previous {
+		t.Error("CaptureDefault did not restore the default logger")
+	}
+}
+
+// TestAssertLoggedFails checks that a missing entry is reported as a failure.
+func TestAssertLoggedFails(t *testing.T) {
+	_, rec := New()
+	ft := &fakeTB{TB: t}
+	rec.AssertLogged(ft, logger.LevelInfo, "never")
+	if !ft.failed {
+		t.Error("AssertLogged should fail when nothing matches")
+	}
+	if !strings.Contains(ft.msg, "level info ") {
+		t.Errorf("failure should name the level: %q", ft.msg)
+	}
+}
+
+// TestAssertFieldValues checks typed integers match untyped ones and slices or maps compare deeply.
+func TestAssertFieldValues(t *testing.T) {
+	l, rec := New()
+	l.LogFields(logger.LevelInfo, "batch", logger.Int("n", 5), logger.Uint64("size", 7))
+	l.Infow("batch", "ids", []int{1, 2}, "tags", map[string]string{"env": "prod"})
+
+	rec.AssertField(t, "batch", "n", 5)
+	rec.AssertField(t, "batch", "size", 7)
+	rec.AssertField(t, "batch", "ids", []int{1, 2})
+	rec.AssertField(t, "batch", "tags", map[string]string{"env": "prod"})
+
+	for key, value := range map[string]interface{}{"ids": []int{2, 1}, "n": "5"} {
+		ft := &fakeTB{TB: t}
+		rec.AssertField(ft, "batch", key, value)
+		if !ft.failed {
+			t.Errorf("AssertField should fail for %s=%v", key, value)
+		}
+	}
+}
+
+// fakeTB records failures instead of failing the enclosing test.
+type fakeTB struct {
+	testing.TB
+	failed bool
+	msg    string
+}
+
+func (f *fakeTB) Helper() {}
+func (f *fakeTB) Errorf(format string, args ...any) {
+	f.failed = true
+	f.msg = fmt.Sprintf(format, args...)
+}
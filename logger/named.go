@@ -0,0 +1,165 @@
+package logger
+
+import (
+	"fmt"
+	"github.com/isaacwallace123/GoUtils/env"
+	"github.com/isaacwallace123/GoUtils/stringutil"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// LevelEnvVar is the environment variable read by the default logger for its level spec.
+const LevelEnvVar = "LOG_LEVEL"
+
+// LevelOverrides is a table of per-name levels for named loggers. A name such as
+// "db.sql" is matched against "db.sql", then "db", before the logger's own level is used.
+// It is safe for concurrent use and is shared between a logger and its children.
+type LevelOverrides struct {
+	mu     sync.Mutex
+	levels atomic.Pointer[map[string]Level]
+}
+
+// NewLevelOverrides returns an empty override table.
+func NewLevelOverrides() *LevelOverrides {
+	o := &LevelOverrides{}
+	o.levels.Store(&map[string]Level{})
+	return o
+}
+
+// Set sets the level for name and every name below it.
+func (o *LevelOverrides) Set(name string, level Level) {
+	o.update(func(m map[string]Level) { m[name] = level })
+}
+
+// Delete removes the override for name.
+func (o *LevelOverrides) Delete(name string) {
+	o.update(func(m map[string]Level) { delete(m, name) })
+}
+
+// Replace swaps the whole table for levels.
+func (o *LevelOverrides) Replace(levels map[string]Level) {
+	o.update(func(m map[string]Level) {
+		clear(m)
+		for name, level := range levels {
+			m[name] = level
+		}
+	})
+}
+
+// update applies fn to a copy of the table and publishes the copy.
+func (o *LevelOverrides) update(fn func(map[string]Level)) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	current := *o.levels.Load()
+	next := make(map[string]Level, len(current)+1)
+	for name, level := range current {
+		next[name] = level
+	}
+	fn(next)
+	o.levels.Store(&next)
+}
+
+// Lookup returns the level for name or its nearest dotted parent.
+func (o *LevelOverrides) Lookup(name string) (Level, bool) {
+	levels := *o.levels.Load()
+	if len(levels) == 0 {
+		return 0, false
+	}
+	for {
+		if level, ok := levels[name]; ok {
+			return level, true
+		}
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 {
+			return 0, false
+		}
+		name = name[:i]
+	}
+}
+
+// ParseLevelSpec parses a spec such as "info,db=debug,http=warn" into a base level
+// and per-name overrides. The base level defaults to INFO when the spec has none.
+func ParseLevelSpec(spec string) (Level, map[string]Level, error) {
+	base := LevelInfo
+	overrides := make(map[string]Level)
+	for _, part := range stringutil.Split(spec, ",") {
+		part = stringutil.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, value, named := strings.Cut(part, "=")
+		if !named {
+			level, err := ParseLevel(part)
+			if err != nil {
+				return 0, nil, err
+			}
+			base = level
+			continue
+		}
+		name = stringutil.TrimSpace(name)
+		if name == "" {
+			return 0, nil, fmt.Errorf("logger: missing name in level spec %q", part)
+		}
+		level, err := ParseLevel(value)
+		if err != nil {
+			return 0, nil, err
+		}
+		overrides[name] = level
+	}
+	return base, overrides, nil
+}
+
+// WithLevelOverrides makes the logger use o for named loggers, so the table can be shared.
+func WithLevelOverrides(o *LevelOverrides) Option {
+	return func(l *Logger) {
+		l.overrides = o
+	}
+}
+
+// WithLevelsFromEnv configures the level and overrides from a spec in the environment
+// variable key, e.g. LOG_LEVEL=info,db=debug,http=warn. Unset or invalid specs are ignored.
+func WithLevelsFromEnv(key string) Option {
+	return func(l *Logger) {
+		spec := env.GetTrimmed(key, "")
+		if spec == "" {
+			return
+		}
+		base, overrides, err := ParseLevelSpec(spec)
+		if err != nil {
+			return
+		}
+		l.level.SetLevel(base)
+		l.overrides.Replace(overrides)
+	}
+}
+
+// LevelOverrides returns the override table shared by the logger and its children.
+func (l *Logger) LevelOverrides() *LevelOverrides {
+	return l.overrides
+}
+
+// Name returns the dotted name of the logger, or "" for a root logger.
+func (l *Logger) Name() string {
+	return l.name
+}
+
+// Named returns a child logger whose name is the parent's name joined with name by a dot.
+// Its level is taken from the override table when the name (or a parent name) has an entry.
+func (l *Logger) Named(name string) *Logger {
+	if name == "" {
+		return l
+	}
+	child := l.clone()
+	if child.name == "" {
+		child.name = name
+	} else {
+		child.name += "." + name
+	}
+	return child
+}
+
+// Named returns a named child of the default logger.
+func Named(name string) *Logger {
+	return Default().Named(name)
+}
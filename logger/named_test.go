@@ -0,0 +1,62 @@
+package logger
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestParseLevelSpec checks base levels, overrides and invalid specs.
+func TestParseLevelSpec(t *testing.T) {
+	base, overrides, err := ParseLevelSpec("warn, db=debug ,http=error")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if base != LevelWarn || overrides["db"] != LevelDebug || overrides["http"] != LevelError {
+		t.Errorf("unexpected spec: base %v, overrides %v", base, overrides)
+	}
+	if base, _, _ := ParseLevelSpec("db=debug"); base != LevelInfo {
+		t.Errorf("base level should default to info, got %v", base)
+	}
+	for _, bad := range []string{"loud", "db=loud", "=debug"} {
+		if _, _, err := ParseLevelSpec(bad); err == nil {
+			t.Errorf("ParseLevelSpec(%q) should fail", bad)
+		}
+	}
+}
+
+// TestNamedOverrides checks hierarchical level resolution for named loggers.
+func TestNamedOverrides(t *testing.T) {
+	var out bytes.Buffer
+	root := New(WithOutput(&out), WithEncoder(LogfmtEncoder{}))
+	root.LevelOverrides().Set("db", LevelDebug)
+	root.LevelOverrides().Set("http", LevelWarn)
+
+	sql := root.Named("db").Named("sql")
+	if sql.Name() != "db.sql" || sql.GetLevel() != LevelDebug {
+		t.Errorf("db.sql should inherit db override, got %q at %v", sql.Name(), sql.GetLevel())
+	}
+	sql.Debug("query")
+	root.Named("http").Info("request") // Should NOT print due to http override
+	root.Named("cache").Info("hit")
+
+	got := out.String()
+	if !strings.Contains(got, "logger=db.sql msg=query") || !strings.Contains(got, "logger=cache msg=hit") {
+		t.Errorf("named entries missing: %q", got)
+	}
+	if strings.Contains(got, "request") {
+		t.Errorf("http override was ignored: %q", got)
+	}
+}
+
+// TestWithLevelsFromEnv checks that the spec is read from the environment.
+func TestWithLevelsFromEnv(t *testing.T) {
+	os.Setenv("GOUTILS_TEST_LOG_LEVEL", "error,db=debug")
+	defer os.Unsetenv("GOUTILS_TEST_LOG_LEVEL")
+
+	l := New(WithLevelsFromEnv("GOUTILS_TEST_LOG_LEVEL"))
+	if l.GetLevel() != LevelError || l.Named("db").GetLevel() != LevelDebug {
+		t.Errorf("env spec not applied: root %v, db %v", l.GetLevel(), l.Named("db").GetLevel())
+	}
+}
@@ -0,0 +1,258 @@
+package logger
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// RedactedValue replaces redacted field values and secrets found in strings.
+const RedactedValue = "[REDACTED]"
+
+// Redactor masks sensitive data in an entry before it is encoded.
+// Redact may replace e.Message and the values in e.Fields; the fields slice is
+// already a private copy.
+type Redactor interface {
+	Redact(e *Entry)
+}
+
+// RedactPattern finds secrets inside strings. Valid, if set, filters out false
+// positives, e.g. digit runs that fail a Luhn check.
+type RedactPattern struct {
+	Regexp *regexp.Regexp
+	Valid  func(match string) bool
+}
+
+// Built-in patterns for common secrets.
+var (
+	JWTPattern         = RedactPattern{Regexp: regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`)}
+	BearerPattern      = RedactPattern{Regexp: regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9\-._~+/]+=*`)}
+	CreditCardPattern  = RedactPattern{Regexp: regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`), Valid: luhnValid}
+	DefaultRedactKeys  = []string{"password", "passwd", "secret", "token", "access_token", "refresh_token", "api_key", "apikey", "authorization", "cookie", "set-cookie", "private_key"}
+	DefaultRedactRules = []RedactPattern{JWTPattern, BearerPattern, CreditCardPattern}
+)
+
+// KeyRedactor masks fields by key (case-insensitive) and secrets matching patterns
+// in the message and in string or error field values. Keys are also matched against
+// the last segment of dotted keys and inside map, slice and struct values.
+type KeyRedactor struct {
+	keys     map[string]struct{}
+	patterns []RedactPattern
+}
+
+// NewRedactor returns a KeyRedactor for the given field keys and patterns.
+func NewRedactor(keys []string, patterns ...RedactPattern) *KeyRedactor {
+	r := &KeyRedactor{keys: make(map[string]struct{}, len(keys)), patterns: patterns}
+	for _, k := range keys {
+		r.keys[strings.ToLower(k)] = struct{}{}
+	}
+	return r
+}
+
+// DefaultRedactor returns a KeyRedactor using DefaultRedactKeys and DefaultRedactRules.
+func DefaultRedactor() *KeyRedactor {
+	return NewRedactor(DefaultRedactKeys, DefaultRedactRules...)
+}
+
+// Redact implements Redactor.
+func (r *KeyRedactor) Redact(e *Entry) {
+	e.Message = r.RedactString(e.Message)
+	for i, f := range e.Fields {
+		if r.secretKey(f.Key) {
+			e.Fields[i] = Field{Key: f.Key, Value: RedactedValue}
+			continue
+		}
+		if f.Kind == StringKind {
+			e.Fields[i].str = r.RedactString(f.str)
+			continue
+		}
+		switch v := f.Value.(type) {
+		case nil:
+		case string:
+			e.Fields[i].Value = r.RedactString(v)
+		case error:
+			text := stringValue(v)
+			if s := r.RedactString(text); s != text {
+				e.Fields[i].Value = s
+			}
+		default:
+			if redacted, changed := r.redactValue(reflect.ValueOf(v), 0); changed {
+				e.Fields[i].Value = redacted
+			}
+		}
+	}
+}
+
+// secretKey reports whether key, or its last dotted segment, is a configured key.
+// Keys of grouped slog attributes such as "auth.password" are matched by their last segment.
+func (r *KeyRedactor) secretKey(key string) bool {
+	key = strings.ToLower(key)
+	if _, ok := r.keys[key]; ok {
+		return true
+	}
+	if i := strings.LastIndexByte(key, '.'); i >= 0 {
+		_, ok := r.keys[key[i+1:]]
+		return ok
+	}
+	return false
+}
+
+// maxRedactDepth bounds how deep redactValue walks nested values.
+const maxRedactDepth = 8
+
+// redactValue walks maps, slices and structs in v, masking values under configured
+// keys and patterns in strings. When something was masked it returns a copy made of
+// map[string]interface{} and []interface{} values; otherwise v is left untouched.
+func (r *KeyRedactor) redactValue(v reflect.Value, depth int) (interface{}, bool) {
+	if depth > maxRedactDepth || !v.IsValid() {
+		return nil, false
+	}
+	switch v.Kind() {
+	case reflect.Interface, reflect.Pointer:
+		if v.IsNil() {
+			return nil, false
+		}
+		return r.redactValue(v.Elem(), depth+1)
+	case reflect.String:
+		if s := r.RedactString(v.String()); s != v.String() {
+			return s, true
+		}
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return nil, false
+		}
+		out := make(map[string]interface{}, v.Len())
+		changed := false
+		for iter := v.MapRange(); iter.Next(); {
+			key := iter.Key().String()
+			out[key], changed = r.redactEntry(key, iter.Value(), depth, changed)
+		}
+		return out, changed
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return nil, false
+		}
+		out := make([]interface{}, v.Len())
+		changed := false
+		for i := range out {
+			elem, ok := r.redactValue(v.Index(i), depth+1)
+			if !ok {
+				elem = exportedValue(v.Index(i))
+			}
+			out[i], changed = elem, changed || ok
+		}
+		return out, changed
+	case reflect.Struct:
+		if !redactableStruct(v) {
+			return nil, false
+		}
+		out := make(map[string]interface{}, v.NumField())
+		changed := false
+		for i := 0; i < v.NumField(); i++ {
+			sf := v.Type().Field(i)
+			name, ok := structFieldName(sf)
+			if !ok {
+				continue
+			}
+			out[name], changed = r.redactEntry(name, v.Field(i), depth, changed)
+		}
+		return out, changed
+	}
+	return nil, false
+}
+
+// redactEntry returns the redacted form of the map or struct member key=v and
+// whether anything has been masked so far.
+func (r *KeyRedactor) redactEntry(key string, v reflect.Value, depth int, changed bool) (interface{}, bool) {
+	if r.secretKey(key) {
+		return RedactedValue, true
+	}
+	if redacted, ok := r.redactValue(v, depth+1); ok {
+		return redacted, true
+	}
+	return exportedValue(v), changed
+}
+
+// exportedValue returns v as an interface, or nil if it cannot be read.
+func exportedValue(v reflect.Value) interface{} {
+	if !v.IsValid() || !v.CanInterface() {
+		return nil
+	}
+	return v.Interface()
+}
+
+// redactableStruct reports whether redactValue should walk the struct v. Structs that
+// render themselves, such as time.Time, and structs without exported fields are kept whole.
+func redactableStruct(v reflect.Value) bool {
+	if v.CanInterface() {
+		switch v.Interface().(type) {
+		case fmt.Stringer, error, json.Marshaler, encoding.TextMarshaler:
+			return false
+		}
+	}
+	for i := 0; i < v.NumField(); i++ {
+		if v.Type().Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+// structFieldName returns the name a struct field is encoded under, honoring json tags.
+func structFieldName(sf reflect.StructField) (string, bool) {
+	if !sf.IsExported() {
+		return "", false
+	}
+	name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return "", false
+	case "":
+		return sf.Name, true
+	}
+	return name, true
+}
+
+// RedactString replaces every pattern match in s with RedactedValue.
+func (r *KeyRedactor) RedactString(s string) string {
+	for _, p := range r.patterns {
+		s = p.Regexp.ReplaceAllStringFunc(s, func(match string) string {
+			if p.Valid != nil && !p.Valid(match) {
+				return match
+			}
+			return RedactedValue
+		})
+	}
+	return s
+}
+
+// luhnValid reports whether the digits in s pass the Luhn checksum.
+func luhnValid(s string) bool {
+	sum, n := 0, 0
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if n%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		n++
+	}
+	return n >= 13 && sum%10 == 0
+}
+
+// WithRedactor masks sensitive data with r before entries reach the sink.
+func WithRedactor(r Redactor) Option {
+	return func(l *Logger) {
+		l.redactor = r
+	}
+}
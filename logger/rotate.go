@@ -0,0 +1,319 @@
+package logger
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// backupTimeFormat is the timestamp embedded in rotated file names. It sorts lexically.
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// RotateOptions controls when a RotatingFile rotates and what it keeps.
+type RotateOptions struct {
+	// MaxSize rotates the file before a write would grow it past this many bytes. 0 disables size rotation.
+	MaxSize int64
+	// Daily rotates the file on the first write of a new day.
+	Daily bool
+	// MaxBackups is the number of rotated files to keep. 0 keeps all of them.
+	MaxBackups int
+	// Compress gzips rotated files in the background.
+	Compress bool
+	// OnError, if set, receives errors that do not stop writes: a rotation that failed
+	// while the current file could be reopened, and background compression and pruning.
+	OnError func(error)
+}
+
+// RotatingFile is an io.WriteCloser that writes to a file and rotates it by size and by day.
+// Rotated files are renamed to name-<timestamp>.ext (plus .gz when compressed).
+// If rotating or reopening fails, writes continue to the current file, which is
+// reopened on the next write if needed.
+// It is safe for concurrent use; pass it to WithOutput or NewWriterSink.
+type RotatingFile struct {
+	mu     sync.Mutex
+	path   string
+	opts   RotateOptions
+	file   *os.File
+	size   int64
+	day    string
+	now    func() time.Time
+	closed bool
+
+	// bgMu serializes background compression and pruning; bg tracks it for Close.
+	bgMu sync.Mutex
+	bg   sync.WaitGroup
+}
+
+// NewRotatingFile opens path for appending, creating it and its directory if needed.
+func NewRotatingFile(path string, opts RotateOptions) (*RotatingFile, error) {
+	f := &RotatingFile{path: path, opts: opts, now: time.Now}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// open opens the current file and records its size and the day it was last written.
+func (f *RotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	f.file = file
+	f.size = info.Size()
+	f.day = dayOf(f.now())
+	if f.size > 0 {
+		f.day = dayOf(info.ModTime())
+	}
+	return nil
+}
+
+// dayOf returns the calendar day of t in its own location.
+func dayOf(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+// Write implements io.Writer, rotating first when p would exceed MaxSize or the day has changed.
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return 0, os.ErrClosed
+	}
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return 0, err
+		}
+	}
+	sizeExceeded := f.opts.MaxSize > 0 && f.size > 0 && f.size+int64(len(p)) > f.opts.MaxSize
+	dayChanged := f.opts.Daily && dayOf(f.now()) != f.day
+	if sizeExceeded || dayChanged {
+		if err := f.rotate(); err != nil {
+			if f.file == nil {
+				return 0, err
+			}
+			// The current file was reopened, so keep the entry and report the failed rotation.
+			f.reportError(err)
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Rotate closes the current file, moves it to a backup and opens a fresh one.
+func (f *RotatingFile) Rotate() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		return os.ErrClosed
+	}
+	return f.rotate()
+}
+
+// Close closes the underlying file and waits for background compression to finish.
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		return nil
+	}
+	f.closed = true
+	var err error
+	if f.file != nil {
+		err = f.file.Close()
+		f.file = nil
+	}
+	f.bg.Wait()
+	return err
+}
+
+// rotate does the work of Rotate: it moves the current file to a backup and reopens
+// the live file straight away. Compression and pruning of old backups run in the
+// background when Compress is set. f.mu must be held.
+func (f *RotatingFile) rotate() error {
+	var closeErr error
+	if f.file != nil {
+		closeErr = f.file.Close()
+		f.file = nil
+	}
+
+	backup := f.backupName(f.now())
+	renameErr := os.Rename(f.path, backup)
+	if os.IsNotExist(renameErr) {
+		renameErr = nil
+	}
+	if err := f.open(); err != nil {
+		return errors.Join(closeErr, renameErr, err)
+	}
+	if renameErr != nil {
+		return errors.Join(closeErr, renameErr)
+	}
+	f.day = dayOf(f.now())
+
+	if !f.opts.Compress {
+		return errors.Join(closeErr, f.prune())
+	}
+	f.bg.Add(1)
+	go func() {
+		defer f.bg.Done()
+		f.bgMu.Lock()
+		defer f.bgMu.Unlock()
+		if err := errors.Join(compressFile(backup), f.prune()); err != nil {
+			f.reportError(err)
+		}
+	}()
+	return closeErr
+}
+
+// reportError passes err to OnError, if set.
+func (f *RotatingFile) reportError(err error) {
+	if f.opts.OnError != nil {
+		f.opts.OnError(err)
+	}
+}
+
+// backupName returns an unused backup path for a rotation at t.
+func (f *RotatingFile) backupName(t time.Time) string {
+	ext := filepath.Ext(f.path)
+	base := strings.TrimSuffix(f.path, ext)
+	name := fmt.Sprintf("%s-%s%s", base, t.Format(backupTimeFormat), ext)
+	for i := 1; fileExists(name) || fileExists(name+".gz"); i++ {
+		name = fmt.Sprintf("%s-%s_%d%s", base, t.Format(backupTimeFormat), i, ext)
+	}
+	return name
+}
+
+// backups returns the existing backup files, oldest first. Only names of the form
+// base-<timestamp>[_N]ext[.gz] count, so unrelated files such as app-access.log are left alone.
+func (f *RotatingFile) backups() ([]string, error) {
+	dir := filepath.Dir(f.path)
+	ext := filepath.Ext(f.path)
+	prefix := strings.TrimSuffix(filepath.Base(f.path), ext) + "-"
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	type backup struct {
+		name string
+		t    time.Time
+		n    int
+	}
+	var found []backup
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		if t, n, ok := parseBackupName(e.Name(), prefix, ext); ok {
+			found = append(found, backup{filepath.Join(dir, e.Name()), t, n})
+		}
+	}
+	sort.Slice(found, func(i, j int) bool {
+		if !found[i].t.Equal(found[j].t) {
+			return found[i].t.Before(found[j].t)
+		}
+		return found[i].n < found[j].n
+	})
+	names := make([]string, len(found))
+	for i, b := range found {
+		names[i] = b.name
+	}
+	return names, nil
+}
+
+// parseBackupName parses prefix<timestamp>[_N]ext[.gz] and returns the timestamp and collision counter.
+func parseBackupName(name, prefix, ext string) (time.Time, int, bool) {
+	rest, ok := strings.CutPrefix(name, prefix)
+	if !ok {
+		return time.Time{}, 0, false
+	}
+	rest = strings.TrimSuffix(rest, ".gz")
+	if rest, ok = strings.CutSuffix(rest, ext); !ok {
+		return time.Time{}, 0, false
+	}
+	n := 0
+	if i := strings.LastIndexByte(rest, '_'); i >= 0 {
+		var err error
+		if n, err = strconv.Atoi(rest[i+1:]); err != nil || n < 1 {
+			return time.Time{}, 0, false
+		}
+		rest = rest[:i]
+	}
+	t, err := time.Parse(backupTimeFormat, rest)
+	if err != nil {
+		return time.Time{}, 0, false
+	}
+	return t, n, true
+}
+
+// prune removes the oldest backups beyond MaxBackups.
+func (f *RotatingFile) prune() error {
+	if f.opts.MaxBackups <= 0 {
+		return nil
+	}
+	names, err := f.backups()
+	if err != nil {
+		return err
+	}
+	for len(names) > f.opts.MaxBackups {
+		if err := os.Remove(names[0]); err != nil {
+			return err
+		}
+		names = names[1:]
+	}
+	return nil
+}
+
+// compressFile gzips path to path.gz and removes the original.
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(dst)
+	if _, err := io.Copy(zw, src); err != nil {
+		zw.Close()
+		dst.Close()
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		dst.Close()
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		return err
+	}
+	src.Close()
+	return os.Remove(path)
+}
+
+// fileExists reports whether path exists.
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,147 @@
+package logger
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testClock returns a clock function that advances by step on every call.
+func testClock(start time.Time, step time.Duration) func() time.Time {
+	t := start
+	return func() time.Time {
+		t = t.Add(step)
+		return t
+	}
+}
+
+// TestRotatingFileSize checks size-based rotation and backup pruning.
+func TestRotatingFileSize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	f, err := NewRotatingFile(path, RotateOptions{MaxSize: 10, MaxBackups: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.now = testClock(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), time.Second)
+	for i := 0; i < 5; i++ {
+		f.Write([]byte("12345678\n"))
+	}
+	f.Close()
+
+	backups, _ := f.backups()
+	if len(backups) != 2 {
+		t.Fatalf("expected 2 backups, got %v", backups)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "12345678\n" {
+		t.Errorf("current file should hold only the last write, got %q", data)
+	}
+}
+
+// TestRotatingFileDaily checks that the first write of a new day rotates and compresses.
+func TestRotatingFileDaily(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	f, err := NewRotatingFile(path, RotateOptions{Daily: true, Compress: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	day := time.Date(2024, 5, 1, 23, 59, 0, 0, time.UTC)
+	f.now = func() time.Time { return day }
+	f.day = dayOf(day)
+	f.Write([]byte("yesterday\n"))
+	day = day.Add(2 * time.Minute)
+	f.Write([]byte("today\n"))
+	f.Close()
+
+	backups, _ := f.backups()
+	if len(backups) != 1 || !strings.HasSuffix(backups[0], ".log.gz") {
+		t.Fatalf("expected one compressed backup, got %v", backups)
+	}
+	gz, _ := os.Open(backups[0])
+	defer gz.Close()
+	zr, err := gzip.NewReader(gz)
+	if err != nil {
+		t.Fatal(err)
+	}
+	old, _ := io.ReadAll(zr)
+	if string(old) != "yesterday\n" {
+		t.Errorf("backup content: got %q", old)
+	}
+}
+
+// TestRotatingFileLogger checks that a RotatingFile works as a logger output.
+func TestRotatingFileLogger(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "app.log")
+	f, err := NewRotatingFile(path, RotateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := New(WithOutput(f), WithEncoder(JSONEncoder{}))
+	l.Info("to file")
+	f.Close()
+	if _, err := f.Write([]byte("x")); err == nil {
+		t.Error("write after Close should fail")
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), `"msg":"to file"`) {
+		t.Errorf("file content: %q", data)
+	}
+}
+
+// TestRotatingFileBackupNames checks pruning only touches files named like backups.
+func TestRotatingFileBackupNames(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	for _, name := range []string{"app-access.log", "app-old.log.gz", "app-2024-05-01T00-00-00.000_x.log"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	f, err := NewRotatingFile(path, RotateOptions{MaxSize: 10, MaxBackups: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.now = testClock(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), time.Second)
+	for i := 0; i < 3; i++ {
+		f.Write([]byte("12345678\n"))
+	}
+	f.Close()
+
+	backups, _ := f.backups()
+	if len(backups) != 1 || !strings.HasPrefix(filepath.Base(backups[0]), "app-2024-05-01T00-00-0") {
+		t.Errorf("expected only the newest backup, got %v", backups)
+	}
+	for _, name := range []string{"app-access.log", "app-old.log.gz", "app-2024-05-01T00-00-00.000_x.log"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("%s should not be pruned: %v", name, err)
+		}
+	}
+}
+
+// TestRotatingFileRecovers checks a failed rotation reports the error and keeps writing to the current file.
+func TestRotatingFileRecovers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	var errs []error
+	f, err := NewRotatingFile(path, RotateOptions{MaxSize: 10, OnError: func(err error) { errs = append(errs, err) }})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	f.Write([]byte("12345678\n"))
+	f.file.Close() // make closing the current file fail during rotation
+
+	if _, err := f.Write([]byte("after\n")); err != nil {
+		t.Fatalf("write after failed rotation: %v", err)
+	}
+	if len(errs) != 1 {
+		t.Errorf("expected one reported error, got %v", errs)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "after\n" {
+		t.Errorf("current file: got %q", data)
+	}
+}
@@ -0,0 +1,191 @@
+package logger
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// SamplerOptions configures a sampling sink.
+type SamplerOptions struct {
+	// Interval is the window counters are kept for. Defaults to one second.
+	Interval time.Duration
+	// First is how many identical entries pass per interval before sampling starts.
+	First int
+	// Thereafter lets every Mth entry through once First is exceeded. 0 drops them all.
+	Thereafter int
+	// OnError, if set, receives errors from writing summaries when an interval ends.
+	OnError func(error)
+}
+
+// sampleKey identifies identical entries.
+type sampleKey struct {
+	level   Level
+	message string
+}
+
+// sampleCounter tracks one key within the current interval.
+type sampleCounter struct {
+	seen       int
+	suppressed int
+	last       Entry
+}
+
+// SamplerSink rate-limits identical entries (same level and message) before passing them on.
+// When an interval in which messages were suppressed ends, it writes one "suppressed X messages"
+// entry per message, carrying the original message as sampled_msg.
+// Call Close on shutdown to write the summaries of the current interval.
+type SamplerSink struct {
+	inner Sink
+	opts  SamplerOptions
+	now   func() time.Time
+
+	mu       sync.Mutex
+	start    time.Time
+	counters map[sampleKey]*sampleCounter
+	timer    *time.Timer
+	interval uint64 // counts rollovers so a late timer does not end the next interval
+	closed   bool
+}
+
+// NewSamplerSink returns a SamplerSink that writes sampled entries to inner.
+func NewSamplerSink(inner Sink, opts SamplerOptions) *SamplerSink {
+	if opts.Interval <= 0 {
+		opts.Interval = time.Second
+	}
+	return &SamplerSink{
+		inner:    inner,
+		opts:     opts,
+		now:      time.Now,
+		counters: make(map[sampleKey]*sampleCounter),
+	}
+}
+
+// Write implements Sink.
+func (s *SamplerSink) Write(e *Entry) error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return ErrSinkClosed
+	}
+	var summaries []*Entry
+	now := s.now()
+	if now.Sub(s.start) >= s.opts.Interval {
+		summaries = s.rollover()
+		s.start = now
+	}
+
+	key := sampleKey{e.Level, e.Message}
+	c, ok := s.counters[key]
+	if !ok {
+		c = &sampleCounter{}
+		s.counters[key] = c
+	}
+	c.seen++
+	keep := c.seen <= s.opts.First ||
+		(s.opts.Thereafter > 0 && (c.seen-s.opts.First)%s.opts.Thereafter == 0)
+	if !keep {
+		c.suppressed++
+		c.last = *e
+		c.last.Fields = nil // pooled with e; summaries carry their own fields
+		if s.timer == nil {
+			interval := s.interval
+			s.timer = time.AfterFunc(s.start.Add(s.opts.Interval).Sub(now), func() { s.tick(interval) })
+		}
+	}
+	s.mu.Unlock()
+
+	if err := s.writeSummaries(summaries); err != nil {
+		return err
+	}
+	if !keep {
+		return nil
+	}
+	return s.inner.Write(e)
+}
+
+// tick ends the given interval when its timer fires and writes the summaries.
+func (s *SamplerSink) tick(interval uint64) {
+	s.mu.Lock()
+	if s.closed || s.interval != interval {
+		s.mu.Unlock()
+		return
+	}
+	summaries := s.rollover()
+	s.start = s.now()
+	s.mu.Unlock()
+
+	if err := s.writeSummaries(summaries); err != nil && s.opts.OnError != nil {
+		s.opts.OnError(err)
+	}
+}
+
+// writeSummaries writes summaries to the wrapped sink, stopping at the first error.
+func (s *SamplerSink) writeSummaries(summaries []*Entry) error {
+	for _, summary := range summaries {
+		if err := s.inner.Write(summary); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rollover resets the counters, stops the interval timer and returns summary entries
+// for suppressed messages. s.mu must be held.
+func (s *SamplerSink) rollover() []*Entry {
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	s.interval++
+	var summaries []*Entry
+	for _, c := range s.counters {
+		if c.suppressed == 0 {
+			continue
+		}
+		summary := c.last
+		summary.Time = s.now()
+		summary.Message = fmt.Sprintf("suppressed %d messages", c.suppressed)
+		summary.Fields = []Field{String("sampled_msg", c.last.Message), F("suppressed", c.suppressed)}
+		summaries = append(summaries, &summary)
+	}
+	s.counters = make(map[sampleKey]*sampleCounter)
+	return summaries
+}
+
+// Flush writes pending suppression summaries, starts a new interval and flushes the wrapped sink.
+func (s *SamplerSink) Flush() error {
+	s.mu.Lock()
+	summaries := s.rollover()
+	s.start = s.now()
+	s.mu.Unlock()
+
+	if err := s.writeSummaries(summaries); err != nil {
+		return err
+	}
+	if f, ok := s.inner.(Flusher); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+// Close writes pending summaries, stops the interval timer and flushes the wrapped sink.
+// Later writes return ErrSinkClosed. It does not close the wrapped sink.
+func (s *SamplerSink) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	summaries := s.rollover()
+	s.closed = true
+	s.mu.Unlock()
+
+	if err := s.writeSummaries(summaries); err != nil {
+		return err
+	}
+	if f, ok := s.inner.(Flusher); ok {
+		return f.Flush()
+	}
+	return nil
+}
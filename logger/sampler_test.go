@@ -0,0 +1,102 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestSamplerSink checks the first-N / every-Mth policy and the summary line.
+func TestSamplerSink(t *testing.T) {
+	mem := &memorySink{}
+	sampler := NewSamplerSink(mem, SamplerOptions{Interval: time.Minute, First: 2, Thereafter: 3})
+	now := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	sampler.now = func() time.Time { return now }
+	l := New(WithSink(sampler))
+
+	for i := 0; i < 10; i++ {
+		l.Warn("disk full")
+	}
+	l.Info("other")
+	// Occurrences 1, 2, 5 and 8 pass; 3, 4, 6, 7, 9 and 10 are suppressed.
+	if got := mem.snapshot(); len(got) != 5 {
+		t.Fatalf("expected 5 entries before rollover, got %v", got)
+	}
+
+	now = now.Add(time.Minute)
+	l.Warn("disk full")
+	got := mem.snapshot()
+	if len(got) != 7 || got[5] != "suppressed 6 messages" || got[6] != "disk full" {
+		t.Errorf("expected summary then fresh entry after rollover, got %v", got)
+	}
+}
+
+// TestSamplerSinkFlush checks that Flush emits pending summaries.
+func TestSamplerSinkFlush(t *testing.T) {
+	mem := &memorySink{}
+	sampler := NewSamplerSink(mem, SamplerOptions{Interval: time.Hour, First: 1})
+	l := New(WithSink(sampler))
+	l.Info("tick")
+	l.Info("tick")
+	l.Info("tick")
+	l.Flush()
+	got := mem.snapshot()
+	if len(got) != 2 || got[1] != "suppressed 2 messages" {
+		t.Errorf("unexpected entries: %v", got)
+	}
+}
+
+// TestSamplerSinkSummaryJSON checks the summary keeps the original message out of the msg key.
+func TestSamplerSinkSummaryJSON(t *testing.T) {
+	var buf bytes.Buffer
+	sampler := NewSamplerSink(NewWriterSink(&buf, JSONEncoder{}), SamplerOptions{Interval: time.Hour, First: 1})
+	l := New(WithSink(sampler), WithCaller(false))
+	l.Info("tick")
+	l.Info("tick")
+	l.Flush()
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || strings.Count(lines[1], `"msg":`) != 1 || !strings.Contains(lines[1], `"sampled_msg":"tick","suppressed":1`) {
+		t.Errorf("unexpected summary: %q", lines)
+	}
+}
+
+// TestSamplerSinkTimer checks summaries are written when the interval ends without further writes.
+func TestSamplerSinkTimer(t *testing.T) {
+	mem := &memorySink{}
+	sampler := NewSamplerSink(mem, SamplerOptions{Interval: 20 * time.Millisecond, First: 1})
+	defer sampler.Close()
+	l := New(WithSink(sampler))
+	l.Info("tick")
+	l.Info("tick")
+	l.Info("tick")
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(mem.snapshot()) < 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("summary not written when the interval ended: %v", mem.snapshot())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got := mem.snapshot(); got[1] != "suppressed 2 messages" {
+		t.Errorf("unexpected entries: %v", got)
+	}
+}
+
+// TestSamplerSinkClose checks Close writes pending summaries and rejects later writes.
+func TestSamplerSinkClose(t *testing.T) {
+	mem := &memorySink{}
+	sampler := NewSamplerSink(mem, SamplerOptions{Interval: time.Hour, First: 1})
+	l := New(WithSink(sampler))
+	l.Info("tick")
+	l.Info("tick")
+	if err := sampler.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got := mem.snapshot(); len(got) != 2 || got[1] != "suppressed 1 messages" {
+		t.Errorf("unexpected entries: %v", got)
+	}
+	if err := sampler.Write(testEntry()); err != ErrSinkClosed {
+		t.Errorf("Write after Close = %v, want ErrSinkClosed", err)
+	}
+}
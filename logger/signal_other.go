@@ -0,0 +1,8 @@
+//go:build !unix
+
+package logger
+
+// HandleLevelSignals is a no-op on platforms without SIGUSR1 and SIGUSR2.
+func HandleLevelSignals(a *AtomicLevel) (stop func()) {
+	return func() {}
+}
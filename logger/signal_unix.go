@@ -0,0 +1,35 @@
+//go:build unix
+
+package logger
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// HandleLevelSignals changes a at runtime: SIGUSR1 steps it down (more verbose)
+// and SIGUSR2 steps it up (quieter). Call the returned function to stop handling.
+func HandleLevelSignals(a *AtomicLevel) (stop func()) {
+	ch := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(ch, syscall.SIGUSR1, syscall.SIGUSR2)
+	go func() {
+		for {
+			select {
+			case sig := <-ch:
+				if sig == syscall.SIGUSR1 {
+					a.SetLevel(stepLevel(a.Level(), -1))
+				} else {
+					a.SetLevel(stepLevel(a.Level(), 1))
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(ch)
+		close(done)
+	}
+}
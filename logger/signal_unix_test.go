@@ -0,0 +1,37 @@
+//go:build unix
+
+package logger
+
+import (
+	"syscall"
+	"testing"
+	"time"
+)
+
+// waitForLevel polls a until it reaches want or a second passes.
+func waitForLevel(a *AtomicLevel, want Level) bool {
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if a.Level() == want {
+			return true
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return false
+}
+
+// TestHandleLevelSignals checks that SIGUSR1 and SIGUSR2 step the level down and up.
+func TestHandleLevelSignals(t *testing.T) {
+	a := NewAtomicLevel(LevelInfo)
+	stop := HandleLevelSignals(a)
+	defer stop()
+
+	syscall.Kill(syscall.Getpid(), syscall.SIGUSR1)
+	if !waitForLevel(a, LevelDebug) {
+		t.Fatalf("SIGUSR1 should lower the level to debug, got %v", a.Level())
+	}
+	syscall.Kill(syscall.Getpid(), syscall.SIGUSR2)
+	if !waitForLevel(a, LevelInfo) {
+		t.Errorf("SIGUSR2 should raise the level back to info, got %v", a.Level())
+	}
+}
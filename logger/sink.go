@@ -0,0 +1,80 @@
+package logger
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// Sink receives the entries produced by a Logger.
+// Implementations must be safe for concurrent use. Entries and their Fields slices
+// are pooled and reused once Write returns, so a sink that keeps e or e.Fields
+// after returning, e.g. to process them on another goroutine, must copy them.
+type Sink interface {
+	Write(e *Entry) error
+}
+
+// bufPool recycles the buffers entries are encoded into.
+var bufPool = sync.Pool{New: func() interface{} { return new(bytes.Buffer) }}
+
+// maxPooledBuffer is the largest buffer returned to bufPool, so one huge entry
+// does not pin its memory for the life of the process.
+const maxPooledBuffer = 64 << 10
+
+// getBuffer returns an empty buffer from bufPool.
+func getBuffer() *bytes.Buffer {
+	buf := bufPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+// putBuffer returns buf to bufPool unless it has grown too large.
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() <= maxPooledBuffer {
+		bufPool.Put(buf)
+	}
+}
+
+// entryPool recycles the entries built by Logger.log.
+var entryPool = sync.Pool{New: func() interface{} { return new(Entry) }}
+
+// getEntry returns an empty entry from entryPool.
+func getEntry() *Entry {
+	return entryPool.Get().(*Entry)
+}
+
+// putEntry clears e, keeping its field slice for reuse, and returns it to entryPool.
+func putEntry(e *Entry) {
+	fields := e.Fields[:0]
+	clear(fields[:cap(fields)])
+	*e = Entry{Fields: fields}
+	entryPool.Put(e)
+}
+
+// writerSink encodes entries and writes each one to an io.Writer in a single call.
+type writerSink struct {
+	mu  sync.Mutex
+	out io.Writer
+	enc Encoder
+}
+
+// NewWriterSink returns a Sink that encodes entries with enc and writes them to w.
+// A TextEncoder left at ColorAuto only colors w if it supports colors.
+// Writes are serialized, so w does not need to be safe for concurrent use.
+func NewWriterSink(w io.Writer, enc Encoder) Sink {
+	return &writerSink{out: w, enc: resolveColor(enc, w)}
+}
+
+// Write implements Sink.
+func (s *writerSink) Write(e *Entry) error {
+	buf := getBuffer()
+	defer putBuffer(buf)
+	if err := s.enc.Encode(buf, e); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err := s.out.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,32 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// TestWriterSinkConcurrent checks that concurrent writes never interleave within a line.
+func TestWriterSinkConcurrent(t *testing.T) {
+	var out bytes.Buffer
+	l := New(WithSink(NewWriterSink(&out, LogfmtEncoder{})))
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			l.Infow("tick", "i", i)
+		}(i)
+	}
+	wg.Wait()
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 20 {
+		t.Fatalf("expected 20 lines, got %d", len(lines))
+	}
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "level=info ") {
+			t.Errorf("malformed line: %q", line)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"time"
+)
+
+// toSlogLevel maps a logger Level to the equivalent slog.Level. Levels share slog's spacing,
+// so custom levels keep their position relative to the built-in ones.
+func toSlogLevel(level Level) slog.Level {
+	return slog.Level(level)
+}
+
+// fromSlogLevel maps a slog.Level to the logger Level with the same priority.
+func fromSlogLevel(level slog.Level) Level {
+	return Level(level)
+}
+
+// SlogHandler is a slog.Handler that renders records through a Logger,
+// so slog output keeps the logger's tags, colors, timestamp format and level.
+type SlogHandler struct {
+	l      *Logger
+	attrs  []Field
+	prefix string
+}
+
+// NewSlogHandler returns a slog.Handler that writes through l.
+// Use slog.New(logger.NewSlogHandler(logger.Default())) to get a *slog.Logger.
+func NewSlogHandler(l *Logger) *SlogHandler {
+	return &SlogHandler{l: l}
+}
+
+// Enabled implements slog.Handler.
+func (h *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.l.Enabled(fromSlogLevel(level))
+}
+
+// Handle implements slog.Handler. Fields attached to ctx with ContextWithFields are included.
+func (h *SlogHandler) Handle(ctx context.Context, r slog.Record) error {
+	ctxFields := FieldsFromContext(ctx)
+	fields := make([]Field, 0, len(ctxFields)+len(h.attrs)+r.NumAttrs())
+	fields = append(append(fields, ctxFields...), h.attrs...)
+	r.Attrs(func(a slog.Attr) bool {
+		fields = appendAttr(fields, h.prefix, a)
+		return true
+	})
+
+	var caller Caller
+	if h.l.addCaller {
+		caller = callerFromPC(r.PC, h.l.callerFunc)
+	}
+	level := fromSlogLevel(r.Level)
+	return h.l.write(&Entry{
+		Time:    r.Time,
+		Level:   level,
+		Tag:     h.l.tag(level),
+		Caller:  caller,
+		Message: r.Message,
+		Fields:  fields,
+	})
+}
+
+// WithAttrs implements slog.Handler.
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	child := *h
+	child.attrs = append([]Field(nil), h.attrs...)
+	for _, a := range attrs {
+		child.attrs = appendAttr(child.attrs, h.prefix, a)
+	}
+	return &child
+}
+
+// WithGroup implements slog.Handler. Keys added after the group are qualified as "group.key".
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	child := *h
+	child.prefix = h.prefix + name + "."
+	return &child
+}
+
+// appendAttr flattens a into fields, qualifying keys with prefix.
+func appendAttr(fields []Field, prefix string, a slog.Attr) []Field {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return fields
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		groupPrefix := prefix
+		if a.Key != "" {
+			groupPrefix += a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			fields = appendAttr(fields, groupPrefix, ga)
+		}
+		return fields
+	}
+	key := prefix + a.Key
+	switch a.Value.Kind() {
+	case slog.KindString:
+		return append(fields, String(key, a.Value.String()))
+	case slog.KindInt64:
+		return append(fields, Int64(key, a.Value.Int64()))
+	case slog.KindUint64:
+		return append(fields, Uint64(key, a.Value.Uint64()))
+	case slog.KindFloat64:
+		return append(fields, Float64(key, a.Value.Float64()))
+	case slog.KindBool:
+		return append(fields, Bool(key, a.Value.Bool()))
+	case slog.KindDuration:
+		return append(fields, Duration(key, a.Value.Duration()))
+	case slog.KindTime:
+		return append(fields, Time(key, a.Value.Time()))
+	}
+	return append(fields, F(key, a.Value.Any()))
+}
+
+// slogAttr converts f to a slog.Attr without boxing typed values.
+func slogAttr(f Field) slog.Attr {
+	switch f.Kind {
+	case StringKind:
+		return slog.String(f.Key, f.str)
+	case IntKind:
+		return slog.Int64(f.Key, int64(f.num))
+	case UintKind:
+		return slog.Uint64(f.Key, f.num)
+	case FloatKind:
+		return slog.Float64(f.Key, math.Float64frombits(f.num))
+	case BoolKind:
+		return slog.Bool(f.Key, f.num == 1)
+	case DurationKind:
+		return slog.Duration(f.Key, time.Duration(f.num))
+	case TimeKind:
+		return slog.Time(f.Key, f.time())
+	}
+	return slog.Any(f.Key, f.Value)
+}
+
+// slogSink forwards entries to a slog.Handler.
+type slogSink struct {
+	h slog.Handler
+}
+
+// NewSlogSink returns a Sink that forwards entries to h, letting Logger calls
+// such as Info feed any slog.Handler. Use it with WithSink.
+func NewSlogSink(h slog.Handler) Sink {
+	return &slogSink{h: h}
+}
+
+// Write implements Sink.
+func (s *slogSink) Write(e *Entry) error {
+	ctx := context.Background()
+	level := toSlogLevel(e.Level)
+	if !s.h.Enabled(ctx, level) {
+		return nil
+	}
+	r := slog.NewRecord(e.Time, level, e.Message, e.Caller.PC)
+	if e.Logger != "" {
+		r.AddAttrs(slog.String("logger", e.Logger))
+	}
+	for _, f := range e.Fields {
+		r.AddAttrs(slogAttr(f))
+	}
+	if e.Stack != "" {
+		r.AddAttrs(slog.String("stack", e.Stack))
+	}
+	return s.h.Handle(ctx, r)
+}
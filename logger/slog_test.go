@@ -0,0 +1,70 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestSlogHandler checks that slog records render in the logger format with attrs and groups.
+func TestSlogHandler(t *testing.T) {
+	var out bytes.Buffer
+	l := New(WithOutput(&out), WithEncoder(TextEncoder{NoColor: true}))
+	s := slog.New(NewSlogHandler(l)).With("service", "api").WithGroup("req")
+	s.Info("handled", "status", 200, slog.Group("user", "id", 7))
+	s.Debug("hidden") // Should NOT print due to log level
+
+	line := out.String()
+	if strings.Count(line, "\n") != 1 {
+		t.Fatalf("expected one line, got %q", line)
+	}
+	if !strings.Contains(line, "[INFO] [slog_test.go:17] handled service=api req.status=200 req.user.id=7") {
+		t.Errorf("unexpected slog line: %q", line)
+	}
+}
+
+// TestSlogHandlerZeroTime checks a record without a time is encoded without one.
+func TestSlogHandlerZeroTime(t *testing.T) {
+	for enc, want := range map[Encoder]string{
+		TextEncoder{NoColor: true}: "[INFO] no time\n",
+		JSONEncoder{}:              `{"level":"info","msg":"no time"}` + "\n",
+		LogfmtEncoder{}:            `level=info msg="no time"` + "\n",
+		SyslogEncoder{Hostname: "h", AppName: "a"}: "<14>1 - h a ",
+	} {
+		var out bytes.Buffer
+		h := NewSlogHandler(New(WithOutput(&out), WithEncoder(enc), WithCaller(false)))
+		h.Handle(context.Background(), slog.NewRecord(time.Time{}, slog.LevelInfo, "no time", 0))
+		if got := out.String(); !strings.HasPrefix(got, want) || strings.Contains(got, "0001") {
+			t.Errorf("%T: got %q, want %q", enc, got, want)
+		}
+	}
+}
+
+// TestSlogSink checks that logger calls are forwarded to a slog.Handler.
+func TestSlogSink(t *testing.T) {
+	var out bytes.Buffer
+	h := slog.NewJSONHandler(&out, &slog.HandlerOptions{Level: slog.LevelWarn})
+	l := New(WithSink(NewSlogSink(h)), WithLevel(LevelDebug))
+	l.Infow("dropped by handler")
+	l.Warnw("low disk", "free", "2GB")
+
+	line := out.String()
+	if strings.Contains(line, "dropped by handler") {
+		t.Errorf("handler level was not respected: %q", line)
+	}
+	if !strings.Contains(line, `"level":"WARN","msg":"low disk","free":"2GB"`) {
+		t.Errorf("unexpected slog JSON: %q", line)
+	}
+}
+
+// TestSlogLevelMapping checks that levels survive a round trip through slog.
+func TestSlogLevelMapping(t *testing.T) {
+	for _, level := range []Level{LevelDebug, LevelInfo, LevelWarn, LevelError, LevelFatal} {
+		if got := fromSlogLevel(toSlogLevel(level)); got != level {
+			t.Errorf("level %d round-tripped to %d", level, got)
+		}
+	}
+}
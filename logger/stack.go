@@ -0,0 +1,132 @@
+package logger
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// maxStackDepth bounds the number of frames captured for a stack trace.
+const maxStackDepth = 64
+
+// WithStacktrace attaches the goroutine stack to entries at or above level,
+// e.g. WithStacktrace(LevelError) for ERROR and FATAL entries.
+func WithStacktrace(level Level) Option {
+	return func(l *Logger) {
+		l.stackLevel = level
+		l.addStack = true
+	}
+}
+
+// captureStack formats the stack starting skip frames above its own caller as
+// "function\n\tfile:line" lines. Leading runtime frames, such as the panic machinery, are dropped.
+func captureStack(skip int) string {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(skip+2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var sb strings.Builder
+	leading := true
+	for {
+		frame, more := frames.Next()
+		if leading && strings.HasPrefix(frame.Function, "runtime.") {
+			if !more {
+				break
+			}
+			continue
+		}
+		leading = false
+		if sb.Len() > 0 {
+			sb.WriteByte('\n')
+		}
+		sb.WriteString(frame.Function)
+		sb.WriteString("\n\t")
+		sb.WriteString(frame.File)
+		sb.WriteByte(':')
+		sb.WriteString(strconv.Itoa(frame.Line))
+		if !more {
+			break
+		}
+	}
+	return sb.String()
+}
+
+// panicCaller returns the frame that panicked, skipping runtime frames above skip.
+func panicCaller(skip int, withFunc bool) Caller {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(skip+2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, "runtime.") {
+			c := Caller{PC: frame.PC, File: frame.File, Line: frame.Line}
+			if withFunc {
+				c.Function = frame.Function
+			}
+			return c
+		}
+		if !more {
+			return Caller{}
+		}
+	}
+}
+
+// logPanic logs a recovered value with the stack of the panicking goroutine.
+// It must be called directly from the deferred recover function.
+func (l *Logger) logPanic(r interface{}) {
+	fields := []Field{F("panic", r)}
+	if e, ok := r.(error); ok {
+		fields = []Field{Err(e)}
+	}
+	var caller Caller
+	if l.addCaller {
+		caller = panicCaller(2, l.callerFunc)
+	}
+	l.write(&Entry{
+		Time:    l.now(),
+		Level:   LevelError,
+		Tag:     l.tag(LevelError),
+		Caller:  caller,
+		Message: fmt.Sprintf("panic: %v", r),
+		Fields:  fields,
+		Stack:   captureStack(2),
+	})
+}
+
+// RecoverAndLog recovers a panic and logs it at ERROR level with its stack. Use it with defer:
+//
+//	defer l.RecoverAndLog()
+func (l *Logger) RecoverAndLog() {
+	if r := recover(); r != nil {
+		l.logPanic(r)
+	}
+}
+
+// RecoverAndRepanic logs a panic like RecoverAndLog and then panics again with the same value.
+func (l *Logger) RecoverAndRepanic() {
+	if r := recover(); r != nil {
+		l.logPanic(r)
+		l.Flush()
+		panic(r)
+	}
+}
+
+// RecoverAndLog recovers a panic and logs it on the default logger with its stack. Use it with defer:
+//
+//	defer logger.RecoverAndLog()
+func RecoverAndLog() {
+	if r := recover(); r != nil {
+		Default().logPanic(r)
+	}
+}
+
+// RecoverAndRepanic logs a panic on the default logger like RecoverAndLog and then panics again with the same value.
+func RecoverAndRepanic() {
+	if r := recover(); r != nil {
+		l := Default()
+		l.logPanic(r)
+		l.Flush()
+		panic(r)
+	}
+}
@@ -0,0 +1,67 @@
+package logger
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestWithStacktrace checks that only entries at or above the configured level carry a stack.
+func TestWithStacktrace(t *testing.T) {
+	sink := &entrySink{}
+	l := New(WithSink(sink), WithStacktrace(LevelError))
+
+	l.Warn("no stack")
+	if sink.last.Stack != "" {
+		t.Errorf("WARN should not carry a stack: %q", sink.last.Stack)
+	}
+	l.Error("with stack")
+	if !strings.HasPrefix(sink.last.Stack, "github.com/isaacwallace123/GoUtils/logger.TestWithStacktrace\n\t") {
+		t.Errorf("stack should start at the caller: %q", sink.last.Stack)
+	}
+}
+
+// panicky panics with v.
+func panicky(v interface{}) {
+	panic(v)
+}
+
+// TestRecoverAndLog checks that panics are recovered and logged with the panicking frame.
+func TestRecoverAndLog(t *testing.T) {
+	sink := &entrySink{}
+	l := New(WithSink(sink), WithCallerFunction(true))
+	func() {
+		defer l.RecoverAndLog()
+		panicky(errors.New("boom"))
+	}()
+
+	e := sink.last
+	if e.Level != LevelError || e.Message != "panic: boom" {
+		t.Errorf("unexpected panic entry: %+v", e)
+	}
+	if !strings.HasSuffix(e.Caller.Function, ".panicky") {
+		t.Errorf("caller should be the panicking function, got %q", e.Caller.Function)
+	}
+	if !strings.HasPrefix(e.Stack, "github.com/isaacwallace123/GoUtils/logger.panicky\n") {
+		t.Errorf("stack should start at the panic site: %q", e.Stack)
+	}
+	if len(e.Fields) != 1 || e.Fields[0].Key != "error" {
+		t.Errorf("error field missing: %v", e.Fields)
+	}
+}
+
+// TestRecoverAndRepanic checks that the panic is logged and then propagated.
+func TestRecoverAndRepanic(t *testing.T) {
+	sink := &entrySink{}
+	l := New(WithSink(sink))
+	defer func() {
+		if r := recover(); r != "again" {
+			t.Errorf("expected re-panic with original value, got %v", r)
+		}
+		if sink.last.Message != "panic: again" {
+			t.Errorf("panic was not logged before re-panicking: %+v", sink.last)
+		}
+	}()
+	defer l.RecoverAndRepanic()
+	panicky("again")
+}
@@ -0,0 +1,303 @@
+package logger
+
+import (
+	"bytes"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Facility is a syslog facility code.
+type Facility int
+
+// Syslog facilities from RFC 5424.
+const (
+	FacilityKern   Facility = 0
+	FacilityUser   Facility = 1
+	FacilityDaemon Facility = 3
+	FacilityAuth   Facility = 4
+	FacilityLocal0 Facility = 16
+	FacilityLocal1 Facility = 17
+	FacilityLocal2 Facility = 18
+	FacilityLocal3 Facility = 19
+	FacilityLocal4 Facility = 20
+	FacilityLocal5 Facility = 21
+	FacilityLocal6 Facility = 22
+	FacilityLocal7 Facility = 23
+)
+
+// SyslogSDID is the structured-data ID under which entry fields are sent.
+// 32473 is the private enterprise number reserved for documentation by RFC 5612.
+const SyslogSDID = "fields@32473"
+
+// syslogTimeFormat is RFC 3339 limited to the microsecond precision RFC 5424 allows.
+const syslogTimeFormat = "2006-01-02T15:04:05.000000Z07:00"
+
+// SyslogSeverity maps a level to its syslog severity: DEBUG is debug (7), INFO is
+// informational (6), levels between INFO and WARN are notice (5), WARN is warning (4),
+// ERROR is err (3), PANIC is crit (2) and FATAL and above are alert (1).
+func SyslogSeverity(level Level) int {
+	switch {
+	case level < LevelInfo:
+		return 7
+	case level == LevelInfo:
+		return 6
+	case level < LevelWarn:
+		return 5
+	case level < LevelError:
+		return 4
+	case level < LevelPanic:
+		return 3
+	case level < LevelFatal:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// SyslogEncoder renders entries as RFC 5424 messages without framing or a trailing newline:
+//
+//	<PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID [fields@32473 k="v"] MSG
+//
+// The logger name is used as MSGID and the caller and fields become structured data.
+// An entry without a time gets the nil value "-" as TIMESTAMP.
+// Framing is left to the transport: SyslogSink adds it, but NewWriterSink does not,
+// so messages written to a plain stream are not separated.
+type SyslogEncoder struct {
+	// Facility defaults to FacilityUser.
+	Facility Facility
+	// Hostname defaults to os.Hostname.
+	Hostname string
+	// AppName defaults to the base name of the executable.
+	AppName string
+}
+
+// Encode implements Encoder.
+func (enc SyslogEncoder) Encode(buf *bytes.Buffer, e *Entry) error {
+	facility := enc.Facility
+	if facility == 0 {
+		facility = FacilityUser
+	}
+	buf.WriteByte('<')
+	buf.WriteString(strconv.Itoa(int(facility)*8 + SyslogSeverity(e.Level)))
+	buf.WriteString(">1 ")
+	if e.Time.IsZero() {
+		buf.WriteByte('-')
+	} else {
+		buf.Write(e.Time.AppendFormat(buf.AvailableBuffer(), syslogTimeFormat))
+	}
+	buf.WriteByte(' ')
+	buf.WriteString(syslogHeader(enc.Hostname, defaultHostname, 255))
+	buf.WriteByte(' ')
+	buf.WriteString(syslogHeader(enc.AppName, defaultAppName, 48))
+	buf.WriteByte(' ')
+	buf.WriteString(strconv.Itoa(os.Getpid()))
+	buf.WriteByte(' ')
+	buf.WriteString(syslogHeader(e.Logger, nil, 32))
+	buf.WriteByte(' ')
+	writeStructuredData(buf, e)
+	buf.WriteByte(' ')
+	buf.WriteString(e.Message)
+	if e.Stack != "" {
+		buf.WriteByte('\n')
+		buf.WriteString(e.Stack)
+	}
+	return nil
+}
+
+// writeStructuredData writes the caller and fields of e as one SD element, or "-" if there are none.
+func writeStructuredData(buf *bytes.Buffer, e *Entry) {
+	if !e.Caller.Defined() && len(e.Fields) == 0 {
+		buf.WriteByte('-')
+		return
+	}
+	buf.WriteString("[" + SyslogSDID)
+	if e.Caller.Defined() {
+		writeSDParam(buf, "caller", e.Caller.String())
+	}
+	for _, f := range e.Fields {
+		writeSDParam(buf, f.Key, f.plainString())
+	}
+	buf.WriteByte(']')
+}
+
+// writeSDParam writes a ` name="value"` pair, sanitizing the name and escaping the value.
+// An empty name, which RFC 5424 does not allow, is written as "_".
+func writeSDParam(buf *bytes.Buffer, name, value string) {
+	if name == "" {
+		name = "_"
+	}
+	buf.WriteByte(' ')
+	buf.WriteString(syslogName(name, 32))
+	buf.WriteString(`="`)
+	for i := 0; i < len(value); i++ {
+		if c := value[i]; c == '"' || c == '\\' || c == ']' {
+			buf.WriteByte('\\')
+		}
+		buf.WriteByte(value[i])
+	}
+	buf.WriteByte('"')
+}
+
+// syslogHeader returns s, or the result of def, as a header field of at most limit characters.
+// An empty value is written as the nil value "-".
+func syslogHeader(s string, def func() string, limit int) string {
+	if s == "" && def != nil {
+		s = def()
+	}
+	if s == "" {
+		return "-"
+	}
+	return syslogName(s, limit)
+}
+
+// syslogName replaces characters that are not printable US-ASCII, spaces, '=', ']' and '"'
+// with '_' and truncates the result to limit bytes.
+func syslogName(s string, limit int) string {
+	b := []byte(s)
+	if len(b) > limit {
+		b = b[:limit]
+	}
+	for i, c := range b {
+		if c <= ' ' || c > '~' || c == '=' || c == ']' || c == '"' {
+			b[i] = '_'
+		}
+	}
+	return string(b)
+}
+
+// defaultHostname returns the host name, or "" if it cannot be determined.
+func defaultHostname() string {
+	name, _ := os.Hostname()
+	return name
+}
+
+// defaultAppName returns the base name of the running executable.
+func defaultAppName() string {
+	if len(os.Args) == 0 {
+		return ""
+	}
+	return filepath.Base(os.Args[0])
+}
+
+// SyslogOptions configures a SyslogSink.
+type SyslogOptions struct {
+	// Network is "unixgram", "unix", "udp" or "tcp". Defaults to "unixgram".
+	Network string
+	// Address is the socket path or host:port. Defaults to /dev/log, which rsyslog,
+	// syslog-ng and systemd-journald listen on.
+	Address string
+	// Encoder holds the facility, hostname and app name of the messages.
+	Encoder SyslogEncoder
+	// Timeout bounds dialing and each write. Defaults to 5 seconds.
+	Timeout time.Duration
+}
+
+// SyslogSink sends entries to a syslog daemon as RFC 5424 messages. Stream
+// connections (tcp and unix) use octet-counting framing from RFC 6587; datagram
+// connections send one message per packet. A failed write is retried once on a
+// fresh connection.
+type SyslogSink struct {
+	opts SyslogOptions
+
+	mu     sync.Mutex
+	conn   net.Conn
+	closed bool
+}
+
+// NewSyslogSink connects to the syslog daemon described by opts.
+func NewSyslogSink(opts SyslogOptions) (*SyslogSink, error) {
+	if opts.Network == "" {
+		opts.Network = "unixgram"
+	}
+	if opts.Address == "" {
+		opts.Address = "/dev/log"
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Second
+	}
+	if opts.Encoder.Hostname == "" {
+		opts.Encoder.Hostname = defaultHostname()
+	}
+	if opts.Encoder.AppName == "" {
+		opts.Encoder.AppName = defaultAppName()
+	}
+	s := &SyslogSink{opts: opts}
+	if err := s.connect(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// connect dials the daemon. s.mu must be held or s must not be shared yet.
+func (s *SyslogSink) connect() error {
+	conn, err := net.DialTimeout(s.opts.Network, s.opts.Address, s.opts.Timeout)
+	if err != nil {
+		return err
+	}
+	s.conn = conn
+	return nil
+}
+
+// stream reports whether the sink's network needs message framing.
+func (s *SyslogSink) stream() bool {
+	return strings.HasPrefix(s.opts.Network, "tcp") || s.opts.Network == "unix"
+}
+
+// Write implements Sink.
+func (s *SyslogSink) Write(e *Entry) error {
+	buf := getBuffer()
+	defer putBuffer(buf)
+	if err := s.opts.Encoder.Encode(buf, e); err != nil {
+		return err
+	}
+	msg := buf.Bytes()
+	if s.stream() {
+		msg = append(strconv.AppendInt(make([]byte, 0, len(msg)+8), int64(len(msg)), 10), ' ')
+		msg = append(msg, buf.Bytes()...)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return ErrSinkClosed
+	}
+	if s.conn == nil {
+		if err := s.connect(); err != nil {
+			return err
+		}
+	}
+	if err := s.send(msg); err == nil {
+		return nil
+	}
+	s.conn.Close()
+	s.conn = nil
+	if err := s.connect(); err != nil {
+		return err
+	}
+	return s.send(msg)
+}
+
+// send writes msg to the current connection. s.mu must be held.
+func (s *SyslogSink) send(msg []byte) error {
+	s.conn.SetWriteDeadline(time.Now().Add(s.opts.Timeout))
+	_, err := s.conn.Write(msg)
+	return err
+}
+
+// Close closes the connection to the daemon. Later writes return ErrSinkClosed.
+func (s *SyslogSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
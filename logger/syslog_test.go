@@ -0,0 +1,146 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestSyslogEncoder checks the RFC 5424 header, structured data escaping and severity mapping.
+func TestSyslogEncoder(t *testing.T) {
+	e := testEntry()
+	e.Logger = "db"
+	e.Fields = append(e.Fields, F("q", `say "hi" [x]`), F("bad key", 1), F("", "anon"))
+
+	var buf bytes.Buffer
+	SyslogEncoder{Facility: FacilityLocal0, Hostname: "web 1", AppName: "api"}.Encode(&buf, e)
+	want := "<132>1 2024-05-01T12:30:45.000000Z web_1 api " + strconv.Itoa(os.Getpid()) + " db " +
+		`[fields@32473 caller="main.go:42" path="/var/log" used="0.93" err="quota" q="say \"hi\" [x\]" bad_key="1" _="anon"] disk almost full`
+	if buf.String() != want {
+		t.Errorf("got  %q\nwant %q", buf.String(), want)
+	}
+
+	for level, want := range map[Level]int{LevelDebug: 7, LevelInfo: 6, LevelInfo + 2: 5, LevelWarn: 4, LevelError: 3, LevelPanic: 2, LevelFatal: 1} {
+		if got := SyslogSeverity(level); got != want {
+			t.Errorf("SyslogSeverity(%v) = %d, want %d", level, got, want)
+		}
+	}
+}
+
+// TestSyslogSinkUDP checks one message is sent per datagram.
+func TestSyslogSinkUDP(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skip(err)
+	}
+	defer pc.Close()
+
+	sink, err := NewSyslogSink(SyslogOptions{Network: "udp", Address: pc.LocalAddr().String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+	New(WithSink(sink), WithCaller(false)).Warnw("disk almost full", "used", 0.93)
+
+	pc.SetReadDeadline(time.Now().Add(time.Second))
+	packet := make([]byte, 2048)
+	n, _, err := pc.ReadFrom(packet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(packet[:n]); !strings.HasPrefix(got, "<12>1 ") || !strings.HasSuffix(got, `[fields@32473 used="0.93"] disk almost full`) {
+		t.Errorf("datagram: %q", got)
+	}
+}
+
+// TestSyslogSinkTCP checks stream messages use octet-counting framing and Close stops writes.
+func TestSyslogSinkTCP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skip(err)
+	}
+	defer ln.Close()
+	received := make(chan string, 2)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		for {
+			prefix, err := r.ReadString(' ')
+			if err != nil {
+				return
+			}
+			n, _ := strconv.Atoi(strings.TrimSpace(prefix))
+			msg := make([]byte, n)
+			if _, err := io.ReadFull(r, msg); err != nil {
+				return
+			}
+			received <- string(msg)
+		}
+	}()
+
+	sink, err := NewSyslogSink(SyslogOptions{Network: "tcp", Address: ln.Addr().String(), Encoder: SyslogEncoder{AppName: "api"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := New(WithSink(sink), WithCaller(false))
+	l.Info("first")
+	l.Named("http").Error("second")
+
+	for _, want := range []string{"<14>1 ", "<11>1 "} {
+		select {
+		case got := <-received:
+			if !strings.HasPrefix(got, want) || !strings.Contains(got, " api ") {
+				t.Errorf("message %q should start with %q", got, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("message not received")
+		}
+	}
+
+	sink.Close()
+	if err := sink.Write(testEntry()); err != ErrSinkClosed {
+		t.Errorf("Write after Close = %v, want ErrSinkClosed", err)
+	}
+}
+
+// TestSyslogSinkUnixgram checks the default datagram transport against a local socket.
+func TestSyslogSinkUnixgram(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unixgram sockets are not supported")
+	}
+	path := filepath.Join(t.TempDir(), "log.sock")
+	pc, err := net.ListenPacket("unixgram", path)
+	if err != nil {
+		t.Skip(err)
+	}
+	defer pc.Close()
+
+	sink, err := NewSyslogSink(SyslogOptions{Address: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+	sink.Write(testEntry())
+
+	pc.SetReadDeadline(time.Now().Add(time.Second))
+	packet := make([]byte, 2048)
+	n, _, err := pc.ReadFrom(packet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(packet[:n]); !strings.HasPrefix(got, "<12>1 2024-05-01T12:30:45.000000Z ") {
+		t.Errorf("datagram: %q", got)
+	}
+}
@@ -0,0 +1,123 @@
+package logger
+
+import (
+	"bytes"
+	"github.com/isaacwallace123/GoUtils/timeutil"
+	"strconv"
+	"time"
+)
+
+// TimeFormat controls how an encoder renders entry timestamps.
+// It is either a time layout such as time.RFC3339 or one of the TimeFormatUnix values.
+// The zero value uses the encoder's default format.
+type TimeFormat string
+
+// Common timestamp formats.
+const (
+	TimeFormatDateTime    TimeFormat = "2006-01-02 15:04:05"
+	TimeFormatMillis      TimeFormat = "2006-01-02 15:04:05.000"
+	TimeFormatRFC3339     TimeFormat = time.RFC3339
+	TimeFormatRFC3339Nano TimeFormat = time.RFC3339Nano
+	// TimeFormatUnix renders the Unix epoch in seconds.
+	TimeFormatUnix TimeFormat = "unix"
+	// TimeFormatUnixMilli renders the Unix epoch in milliseconds.
+	TimeFormatUnixMilli TimeFormat = "unixms"
+	// TimeFormatUnixNano renders the Unix epoch in nanoseconds.
+	TimeFormatUnixNano TimeFormat = "unixns"
+)
+
+// epoch reports whether f renders a Unix epoch number, and returns it for t.
+func (f TimeFormat) epoch(t time.Time) (int64, bool) {
+	switch f {
+	case TimeFormatUnix:
+		return timeutil.ToUnixSeconds(t), true
+	case TimeFormatUnixMilli:
+		return timeutil.ToUnixMilliseconds(t), true
+	case TimeFormatUnixNano:
+		return timeutil.ToUnixNanoseconds(t), true
+	}
+	return 0, false
+}
+
+// or returns f, or def when f is the zero value.
+func (f TimeFormat) or(def TimeFormat) TimeFormat {
+	if f == "" {
+		return def
+	}
+	return f
+}
+
+// Format renders t in the format.
+func (f TimeFormat) Format(t time.Time) string {
+	return string(f.appendTo(nil, t))
+}
+
+// appendTo appends t rendered in the format to dst.
+func (f TimeFormat) appendTo(dst []byte, t time.Time) []byte {
+	if n, ok := f.epoch(t); ok {
+		return strconv.AppendInt(dst, n, 10)
+	}
+	return t.AppendFormat(dst, string(f))
+}
+
+// appendJSON writes t as a JSON value: a number for epoch formats and a string otherwise.
+func (f TimeFormat) appendJSON(buf *bytes.Buffer, t time.Time) {
+	if n, ok := f.epoch(t); ok {
+		buf.Write(strconv.AppendInt(buf.AvailableBuffer(), n, 10))
+		return
+	}
+	start := buf.Len()
+	buf.WriteByte('"')
+	ts := t.AppendFormat(buf.AvailableBuffer(), string(f))
+	if !jsonSafe(ts) {
+		buf.Truncate(start)
+		writeJSONString(buf, string(ts))
+		return
+	}
+	buf.Write(ts)
+	buf.WriteByte('"')
+}
+
+// WithTimeFormat sets the timestamp format of the logger's encoder.
+// It applies to the default encoder and to built-in encoders passed to WithEncoder
+// that do not set a TimeFormat themselves.
+func WithTimeFormat(f TimeFormat) Option {
+	return func(l *Logger) {
+		l.timeFormat = f
+	}
+}
+
+// WithTimeLocation renders entry timestamps in loc instead of local time.
+func WithTimeLocation(loc *time.Location) Option {
+	return func(l *Logger) {
+		l.location = loc
+	}
+}
+
+// WithUTC renders entry timestamps in UTC.
+func WithUTC() Option {
+	return WithTimeLocation(time.UTC)
+}
+
+// WithClock sets the function used to timestamp entries, e.g. a fixed time in tests.
+func WithClock(now func() time.Time) Option {
+	return func(l *Logger) {
+		l.now = now
+	}
+}
+
+// applyTimeFormat sets f on enc if it is a built-in encoder without its own format.
+func applyTimeFormat(enc Encoder, f TimeFormat) Encoder {
+	switch e := enc.(type) {
+	case TextEncoder:
+		e.TimeFormat = e.TimeFormat.or(f)
+		return e
+	case JSONEncoder:
+		e.TimeFormat = e.TimeFormat.or(f)
+		return e
+	case LogfmtEncoder:
+		e.TimeFormat = e.TimeFormat.or(f)
+		return e
+	}
+	return enc
+}
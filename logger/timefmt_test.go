@@ -0,0 +1,74 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestTimeFormat checks layouts and epoch formats render as expected.
+func TestTimeFormat(t *testing.T) {
+	ts := time.Date(2024, 5, 1, 12, 30, 45, 123456789, time.UTC)
+	cases := map[TimeFormat]string{
+		TimeFormatDateTime:    "2024-05-01 12:30:45",
+		TimeFormatMillis:      "2024-05-01 12:30:45.123",
+		TimeFormatRFC3339Nano: "2024-05-01T12:30:45.123456789Z",
+		TimeFormatUnix:        "1714566645",
+		TimeFormatUnixMilli:   "1714566645123",
+		TimeFormat("15:04"):   "12:30",
+	}
+	for f, want := range cases {
+		if got := f.Format(ts); got != want {
+			t.Errorf("%q: got %q, want %q", f, got, want)
+		}
+	}
+}
+
+// TestEncoderTimeFormat checks each built-in encoder honors its TimeFormat.
+func TestEncoderTimeFormat(t *testing.T) {
+	var buf bytes.Buffer
+	TextEncoder{NoColor: true, TimeFormat: TimeFormatMillis}.Encode(&buf, testEntry())
+	if !strings.HasPrefix(buf.String(), "[2024-05-01 12:30:45.000] ") {
+		t.Errorf("text: %q", buf.String())
+	}
+
+	buf.Reset()
+	JSONEncoder{TimeFormat: TimeFormatUnix}.Encode(&buf, testEntry())
+	if !strings.HasPrefix(buf.String(), `{"time":1714566645,`) {
+		t.Errorf("json: %q", buf.String())
+	}
+
+	buf.Reset()
+	LogfmtEncoder{TimeFormat: TimeFormatDateTime}.Encode(&buf, testEntry())
+	if !strings.HasPrefix(buf.String(), `level=warn ts="2024-05-01 12:30:45" `) {
+		t.Errorf("logfmt: %q", buf.String())
+	}
+}
+
+// TestLoggerClockAndLocation checks WithClock, WithUTC and WithTimeFormat on a logger.
+func TestLoggerClockAndLocation(t *testing.T) {
+	zone := time.FixedZone("EST", -5*60*60)
+	clock := func() time.Time { return time.Date(2024, 5, 1, 7, 30, 45, 0, zone) }
+
+	var buf bytes.Buffer
+	l := New(WithOutput(&buf), WithClock(clock), WithUTC(), WithTimeFormat(TimeFormatRFC3339), WithCaller(false))
+	l.Info("hello")
+	if !strings.HasPrefix(buf.String(), "[2024-05-01T12:30:45Z] ") {
+		t.Errorf("default encoder: %q", buf.String())
+	}
+
+	buf.Reset()
+	l = New(WithOutput(&buf), WithClock(clock), WithEncoder(JSONEncoder{}), WithTimeFormat(TimeFormatUnixMilli))
+	l.Info("hello")
+	if !strings.HasPrefix(buf.String(), `{"time":1714566645000,`) {
+		t.Errorf("JSON encoder: %q", buf.String())
+	}
+
+	buf.Reset()
+	l = New(WithOutput(&buf), WithClock(clock), WithEncoder(LogfmtEncoder{TimeFormat: TimeFormatUnix}), WithTimeFormat(TimeFormatMillis))
+	l.Info("hello")
+	if !strings.HasPrefix(buf.String(), "level=info ts=1714566645 ") {
+		t.Errorf("an encoder's own TimeFormat should win: %q", buf.String())
+	}
+}
@@ -2,6 +2,7 @@ package timeutil
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -115,6 +116,21 @@ func FormatDuration(seconds float64) string {
 	return result
 }
 
+// FormatLatency formats a duration for request logs: sub-second values keep
+// millisecond or microsecond precision and values of a minute or more use FormatDuration.
+func FormatLatency(d time.Duration) string {
+	switch {
+	case d < time.Millisecond:
+		return fmt.Sprintf("%dµs", d.Microseconds())
+	case d < time.Second:
+		return fmt.Sprintf("%.1fms", float64(d)/float64(time.Millisecond))
+	case d < time.Minute:
+		return fmt.Sprintf("%.2fs", d.Seconds())
+	default:
+		return strings.TrimSpace(FormatDuration(d.Seconds()))
+	}
+}
+
 // Roblox-style DateTime methods
 
 func DateTimeNow() time.Time {
@@ -94,3 +94,19 @@ func TestFormatDuration(t *testing.T) {
 		t.Errorf("FormatDuration unexpected: %q", d)
 	}
 }
+
+// TestFormatLatency checks precision at each duration scale.
+func TestFormatLatency(t *testing.T) {
+	cases := map[time.Duration]string{
+		850 * time.Microsecond:  "850µs",
+		1234 * time.Microsecond: "1.2ms",
+		2500 * time.Millisecond: "2.50s",
+		2 * time.Minute:         "2m",
+		time.Hour + time.Second: "1h 1s",
+	}
+	for d, want := range cases {
+		if got := FormatLatency(d); got != want {
+			t.Errorf("FormatLatency(%v) = %q, want %q", d, got, want)
+		}
+	}
+}